@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/log"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/j178/leetgo/lang"
+	"github.com/j178/leetgo/leetcode"
+)
+
+var complexityMaxSize int
+
+var complexityCmd = &cobra.Command{
+	Use:   "complexity qid",
+	Short: "Estimate time complexity of your solution empirically",
+	Long: `Run your local solution on random inputs of increasing size, and fit the timings against
+common complexity classes like O(n), O(n log n) and O(n²).`,
+	Example: `leetgo complexity 1
+leetgo complexity last --max 100000`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c := leetcode.NewClient(leetcode.WithCredentials(leetcode.CredentialsFromConfig()))
+		qs, err := leetcode.ParseQID(args[0], c)
+		if err != nil {
+			return err
+		}
+		if len(qs) > 1 {
+			return fmt.Errorf("multiple questions found")
+		}
+		q := qs[0]
+
+		spin := newSpinner(cmd.ErrOrStderr())
+		spin.Suffix = " Running solution on scaled inputs..."
+		spin.Start()
+		report, err := lang.EstimateComplexity(q, complexityMaxSize)
+		spin.Stop()
+		if report != nil {
+			w := table.NewWriter()
+			w.SetOutputMirror(os.Stdout)
+			w.SetStyle(table.StyleColoredDark)
+			w.AppendHeader(table.Row{"Size", "Time"})
+			for _, s := range report.Samples {
+				if s.Err != nil {
+					w.AppendRow(table.Row{s.Size, s.Err})
+				} else {
+					w.AppendRow(table.Row{s.Size, s.Elapsed})
+				}
+			}
+			w.Render()
+		}
+		if err != nil {
+			return err
+		}
+
+		best := report.Best()
+		log.Info("best fit", "complexity", best.Model)
+		if projected := best.Project(report.MaxSize); projected == lang.MaxProjection {
+			log.Info("projected runtime", "size", report.MaxSize, "time", "longer than "+projected.String())
+		} else {
+			log.Info("projected runtime", "size", report.MaxSize, "time", projected)
+		}
+		if len(report.Fits) > 1 {
+			log.Debug("second best fit", "complexity", report.Fits[1].Model, "residual", report.Fits[1].Residual)
+		}
+		return nil
+	},
+}
+
+func init() {
+	complexityCmd.Flags().IntVar(
+		&complexityMaxSize,
+		"max",
+		0,
+		"maximum input size to project runtime, guessed from constraints if not set",
+	)
+}
@@ -188,18 +188,39 @@ leetgo contest left w330
 			return err
 		}
 
-		generated, err := lang.GenerateContest(contest)
-		if err != nil {
+		var generated []*lang.GenerateResult
+		err = forEachLang(
+			func(gen lang.Lang) error {
+				results, err := lang.GenerateContest(contest)
+				if err != nil {
+					return err
+				}
+				generated = append(generated, results...)
+				return nil
+			},
+		)
+		if err != nil || lang.IsDryRun() {
 			return err
 		}
 
 		isSet := cmd.Flags().Lookup("browser").Changed
 		if (isSet && openInBrowser) || (!isSet && cfg.Contest.OpenInBrowser) {
+			// Each question is generated once per language, open it only once.
+			opened := make(map[string]bool)
 			for _, r := range generated {
-				_ = browser.OpenURL(r.Question.ContestUrl())
+				if !opened[r.Question.TitleSlug] {
+					opened[r.Question.TitleSlug] = true
+					_ = browser.OpenURL(r.Question.ContestUrl())
+				}
+			}
+		}
+		var first []*lang.GenerateResult
+		for _, r := range generated {
+			if r.Question.TitleSlug == generated[0].Question.TitleSlug {
+				first = append(first, r)
 			}
 		}
-		err = editor.Open(generated[0])
+		err = editor.Open(first...)
 		return err
 	},
 }
@@ -269,5 +290,6 @@ var unregisterCmd = &cobra.Command{
 
 func init() {
 	contestCmd.Flags().BoolVarP(&openInBrowser, "browser", "b", false, "open question page in browser")
+	addSynthesizeFlag(contestCmd)
 	contestCmd.AddCommand(unregisterCmd)
 }
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/lang"
+)
+
+var doctorReinit bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check and repair the workspace of the current language",
+	Long: `Check whether the project scaffold of the current language is in place, and create it if not.
+
+Use --reinit to run the initialization again even if the workspace looks fine, e.g. after upgrading leetgo.`,
+	Example: "leetgo doctor\nleetgo doctor -l rust --reinit",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.Get()
+		gen, err := lang.GetGenerator(cfg.Code.Lang)
+		if err != nil {
+			return err
+		}
+		outDir, err := lang.InitWorkspace(gen, cfg.ProjectRoot(), doctorReinit)
+		if err != nil {
+			return err
+		}
+		ok, err := lang.HasInitialized(gen, outDir)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			log.Warn("workspace is still incomplete, please check the output above", "lang", gen.Slug(), "dir", outDir)
+			return nil
+		}
+		log.Info("workspace is ready", "lang", gen.Slug(), "dir", outDir)
+		return nil
+	},
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorReinit, "reinit", false, "run the initialization even if it has been done")
+}
@@ -11,13 +11,13 @@ import (
 )
 
 var editCmd = &cobra.Command{
-	Use:     "edit qid",
+	Use:     "edit [qid | path]",
 	Short:   "Open solution in editor",
 	Aliases: []string{"e"},
-	Args:    cobra.ExactArgs(1),
+	Args:    cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		c := leetcode.NewClient()
-		qs, err := leetcode.ParseQID(args[0], c)
+		qs, err := questionsFromArgs(cmd, args, c)
 		if err != nil {
 			return err
 		}
@@ -32,14 +32,19 @@ var editCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	addVariantFlag(editCmd)
+	addVariantFlag(extractCmd)
+}
+
 var extractCmd = &cobra.Command{
-	Use:    "extract qid",
+	Use:    "extract [qid | path]",
 	Short:  "Extract solution code from generated file",
-	Args:   cobra.ExactArgs(1),
+	Args:   cobra.MaximumNArgs(1),
 	Hidden: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		c := leetcode.NewClient()
-		qs, err := leetcode.ParseQID(args[0], c)
+		qs, err := questionsFromArgs(cmd, args, c)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/j178/leetgo/lang"
+	"github.com/j178/leetgo/leetcode"
+	"github.com/j178/leetgo/utils"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import DIR",
+	Short: "Import solutions written with vscode-leetcode or leetcode-cli",
+	Long: `Import solutions written with other LeetCode tools, like vscode-leetcode and leetcode-cli.
+
+The question and language of a file are detected from its "@lc app=... id=... lang=..." header,
+or from its filename like "1.two-sum.go". leetgo files are generated for the question,
+and the code between "@lc code=start" and "@lc code=end" of the old file is put into the new code file.
+Questions that have been generated are skipped.`,
+	Example: `leetgo import ~/.leetcode
+leetgo import ./old-solutions --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c := leetcode.NewClient(leetcode.WithCredentials(leetcode.CredentialsFromConfig()))
+
+		var imported, skipped, unknown []string
+		err := filepath.WalkDir(
+			args[0], func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.IsDir() {
+					if path != args[0] && strings.HasPrefix(d.Name(), ".") {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				rel := utils.RelToCwd(path)
+				s, err := lang.ParseImportedSolution(path, c)
+				if errors.Is(err, lang.ErrUnknownSolution) {
+					unknown = append(unknown, rel)
+					return nil
+				}
+				if err != nil {
+					skipped = append(skipped, rel+": "+err.Error())
+					return nil
+				}
+				result, err := lang.ImportSolution(s)
+				if err != nil {
+					skipped = append(skipped, rel+": "+err.Error())
+					return nil
+				}
+				imported = append(imported, rel+" -> "+utils.RelToCwd(result.GetFile(lang.CodeFile).GetPath()))
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, section := range []struct {
+			title string
+			lines []string
+		}{
+			{"Imported", imported},
+			{"Skipped", skipped},
+			{"Unknown", unknown},
+		} {
+			cmd.Printf("%s (%d):\n", section.title, len(section.lines))
+			for _, line := range section.lines {
+				cmd.Printf("  %s\n", line)
+			}
+		}
+		return nil
+	},
+}
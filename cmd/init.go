@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/lang"
 	"github.com/j178/leetgo/leetcode"
 	"github.com/j178/leetgo/utils"
 )
@@ -19,7 +20,11 @@ var initCmd = &cobra.Command{
 	Use:     "init [DIR]",
 	Short:   "Init a leetcode workspace",
 	Example: "leetgo init -t us -l cpp",
-	Args:    cobra.MaximumNArgs(1),
+	Long: `Init a leetcode workspace.
+
+If --lang is given, the project scaffold of that language (e.g. go.mod, Cargo.toml, CMakeLists.txt)
+is also created right away, instead of on the first pick.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dir := "."
 		if len(args) > 0 {
@@ -40,6 +45,12 @@ var initCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if cmd.Flags().Changed("lang") {
+			err = initLangWorkspace(dir)
+			if err != nil {
+				return err
+			}
+		}
 		err = createQuestionCache()
 		return err
 	},
@@ -123,6 +134,23 @@ leetcode:
 	return nil
 }
 
+func initLangWorkspace(dir string) error {
+	gen, err := lang.GetGenerator(config.Get().Code.Lang)
+	if err != nil {
+		return err
+	}
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	outDir, err := lang.InitWorkspace(gen, root, false)
+	if err != nil {
+		return err
+	}
+	log.Info("workspace initialized", "lang", gen.Slug(), "dir", outDir)
+	return nil
+}
+
 func createQuestionCache() error {
 	c := leetcode.NewClient()
 	cache := leetcode.GetCache(c)
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/j178/leetgo/lang"
+	"github.com/j178/leetgo/leetcode"
+	"github.com/j178/leetgo/utils"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show curated question lists",
+	Long: `Show curated question lists like Blind 75, NeetCode 150 and LeetCode 75.
+
+A list can be used as a qid, e.g. "leetgo pick list:blind75".
+You can define your own lists in leetgo.yaml under the "lists" key.`,
+	Example: `leetgo list
+leetgo list show blind75`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := table.NewWriter()
+		w.SetOutputMirror(cmd.OutOrStdout())
+		w.SetStyle(table.StyleColoredDark)
+		w.AppendHeader(table.Row{"Name", "Title", "Questions", "Source"})
+		for _, name := range leetcode.StudyListNames() {
+			l, err := leetcode.GetStudyList(name)
+			if err != nil {
+				return err
+			}
+			source := "config"
+			if l.BuiltIn {
+				source = "builtin"
+			}
+			w.AppendRow(table.Row{l.Name, l.Title, len(l.Items), source})
+		}
+		w.Render()
+		return nil
+	},
+}
+
+var listShowCmd = &cobra.Command{
+	Use:     "show name",
+	Short:   "Show questions of a list and your progress",
+	Example: "leetgo list show blind75",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l, err := leetcode.GetStudyList(args[0])
+		if err != nil {
+			return err
+		}
+		c := leetcode.NewClient(leetcode.WithCredentials(leetcode.CredentialsFromConfig()))
+		qs := l.Questions(c)
+
+		w := table.NewWriter()
+		w.SetOutputMirror(cmd.OutOrStdout())
+		w.SetStyle(table.StyleColoredDark)
+		w.SetTitle(l.Title)
+		w.AppendHeader(table.Row{"#", "Question", "Difficulty", "Generated", "Accepted"})
+		generated, accepted := 0, 0
+		for i, q := range qs {
+			gen := ""
+			if result, err := lang.GeneratePathsOnly(q); err == nil {
+				if f := result.GetFile(lang.CodeFile); f != nil && utils.IsExist(f.GetPath()) {
+					gen = "√"
+					generated++
+				}
+			}
+			ac := ""
+			if strings.EqualFold(q.Status, "ac") {
+				ac = "√"
+				accepted++
+			}
+			w.AppendRow(
+				table.Row{i + 1, q.QuestionFrontendId + ". " + q.GetTitle(), q.Difficulty, gen, ac},
+			)
+		}
+		w.AppendFooter(table.Row{"", "Total", len(qs), generated, accepted})
+		w.Render()
+		return nil
+	},
+}
+
+func init() {
+	listCmd.AddCommand(listShowCmd)
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/j178/leetgo/lang"
+	"github.com/j178/leetgo/leetcode"
+	"github.com/j178/leetgo/utils"
+)
+
+var migrateLayoutCmd = &cobra.Command{
+	Use:   "migrate-layout",
+	Short: "Move existing solutions to the paths of the current filename_template and out_dir",
+	Long: `Move existing solutions to the paths of the current filename_template and out_dir.
+
+Solutions are found in the project by the question URL in their header, the metadata file of
+their question directory, recently generated questions named in their path, or by the question ID
+their file or directory name starts with. Solutions whose question can't be found are reported and left in place. Question directories (like Go) are moved as a whole,
+together with testcases.txt and variants. Files are moved with "git mv" inside a git repository.
+Use --dry-run to see the moves without doing them.`,
+	Example: `leetgo migrate-layout --dry-run
+leetgo migrate-layout -l go,python3`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c := leetcode.NewClient(leetcode.WithCredentials(leetcode.CredentialsFromConfig()))
+		return forEachLang(
+			func(gen lang.Lang) error {
+				moves, unidentified, err := lang.PlanLayoutMigration(c)
+				if err != nil {
+					return err
+				}
+				for _, f := range unidentified {
+					log.Warn("cannot find the question of the solution, skipped", "file", utils.RelToCwd(f))
+				}
+				if len(moves) == 0 {
+					log.Info("layout is up to date", "lang", gen.Slug())
+					return nil
+				}
+				for _, m := range moves {
+					cmd.Printf("%s -> %s\n", utils.RelToCwd(m.From), utils.RelToCwd(m.To))
+				}
+				if lang.IsDryRun() {
+					return nil
+				}
+
+				accept := true
+				if !viper.GetBool("yes") {
+					err = survey.AskOne(
+						&survey.Confirm{
+							Message: fmt.Sprintf("Move %d %s solutions?", len(moves), gen.Name()),
+						}, &accept,
+					)
+					if err != nil {
+						return err
+					}
+				}
+				if !accept {
+					return nil
+				}
+				return lang.MigrateLayout(moves)
+			},
+		)
+	},
+}
@@ -1,16 +1,22 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"sync"
 
 	"github.com/AlecAivazis/survey/v2"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 
 	"github.com/j178/leetgo/editor"
 	"github.com/j178/leetgo/lang"
 	"github.com/j178/leetgo/leetcode"
+	"github.com/j178/leetgo/utils"
 )
 
 func askFilter(c leetcode.Client) (filter leetcode.QuestionFilter, err error) {
@@ -86,13 +92,36 @@ func askFilter(c leetcode.Client) (filter leetcode.QuestionFilter, err error) {
 	return filter, nil
 }
 
+var (
+	pickTags       []string
+	pickDifficulty string
+	pickStatus     string
+	pickLimit      int
+)
+
+// maxFulfillWorkers is the maximum number of questions fetched at the same time.
+const maxFulfillWorkers = 4
+
+func init() {
+	pickCmd.Flags().StringSliceVar(&pickTags, "tag", nil, "pick questions with these tags, e.g. dynamic-programming")
+	pickCmd.Flags().StringVar(&pickDifficulty, "difficulty", "", "pick questions of this difficulty: easy, medium or hard")
+	pickCmd.Flags().StringVar(&pickStatus, "status", "", "pick questions of this status: notstarted, notac or ac")
+	pickCmd.Flags().IntVar(&pickLimit, "limit", 20, "maximum number of questions to pick by filters")
+	addVariantFlag(pickCmd)
+	addSynthesizeFlag(pickCmd)
+}
+
 var pickCmd = &cobra.Command{
 	Use:   "pick [qid]",
 	Short: "Generate a new question",
 	Example: `leetgo pick  # show a list of questions to pick
 leetgo pick today
 leetgo pick 549
-leetgo pick two-sum`,
+leetgo pick two-sum
+leetgo pick 322 --variant dp
+leetgo pick --tag dynamic-programming --difficulty medium --status notac --limit 20
+leetgo pick @questions.txt  # one qid per line
+leetgo pick list:blind75`,
 	Args:    cobra.MaximumNArgs(1),
 	Aliases: []string{"p"},
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -100,6 +129,24 @@ leetgo pick two-sum`,
 		c := leetcode.NewClient(leetcode.WithCredentials(cred))
 		var q *leetcode.QuestionData
 
+		if len(args) > 0 && strings.HasPrefix(args[0], "@") {
+			qs, err := readQIDFile(strings.TrimPrefix(args[0], "@"), c)
+			if err != nil {
+				return err
+			}
+			return pickBatch(cmd, qs)
+		}
+		if hasPickFilter(cmd) {
+			if len(args) > 0 {
+				return errors.New("qid cannot be used together with filters")
+			}
+			qs, err := questionsByFilter(c)
+			if err != nil {
+				return err
+			}
+			return pickBatch(cmd, qs)
+		}
+
 		if len(args) > 0 {
 			qid := args[0]
 			qs, err := leetcode.ParseQID(qid, c)
@@ -107,7 +154,10 @@ leetgo pick two-sum`,
 				return err
 			}
 			if len(qs) > 1 {
-				return fmt.Errorf("`leetgo pick` cannot handle multiple contest questions, use `leetgo contest` instead")
+				if strings.Contains(qid, "/") {
+					return fmt.Errorf("`leetgo pick` cannot handle multiple contest questions, use `leetgo contest` instead")
+				}
+				return pickBatch(cmd, qs)
 			}
 			q = qs[0]
 		} else {
@@ -126,11 +176,193 @@ leetgo pick two-sum`,
 			q = m.Selected()
 		}
 
-		result, err := lang.Generate(q)
-		if err != nil {
+		var results []*lang.GenerateResult
+		err := forEachLang(
+			func(gen lang.Lang) error {
+				result, err := lang.Generate(q)
+				if err != nil {
+					return err
+				}
+				results = append(results, result)
+				return nil
+			},
+		)
+		if err != nil || lang.IsDryRun() {
 			return err
 		}
-		err = editor.Open(result)
+		err = editor.Open(results...)
 		return err
 	},
 }
+
+func hasPickFilter(cmd *cobra.Command) bool {
+	for _, name := range []string{"tag", "difficulty", "status"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// questionsByFilter pages through the question list until --limit questions are found.
+func questionsByFilter(c leetcode.Client) ([]*leetcode.QuestionData, error) {
+	filter := leetcode.QuestionFilter{
+		Difficulty: strings.ToUpper(pickDifficulty),
+		Tags:       pickTags,
+	}
+	switch strings.ToLower(strings.ReplaceAll(pickStatus, "_", "")) {
+	case "":
+	case "notstarted", "todo":
+		filter.Status = "NOT_STARTED"
+	case "notac", "tried":
+		filter.Status = "TRIED"
+	case "ac":
+		filter.Status = "AC"
+	default:
+		return nil, fmt.Errorf("invalid status %s, only notstarted, notac or ac is supported", pickStatus)
+	}
+	switch filter.Difficulty {
+	case "", "EASY", "MEDIUM", "HARD":
+	default:
+		return nil, fmt.Errorf("invalid difficulty %s, only easy, medium or hard is supported", pickDifficulty)
+	}
+	if pickLimit <= 0 {
+		return nil, errors.New("--limit must be positive")
+	}
+
+	const pageSize = 100
+	var qs []*leetcode.QuestionData
+	for skip := 0; len(qs) < pickLimit; skip += pageSize {
+		list, err := c.GetQuestionsByFilter(filter, pageSize, skip)
+		if err != nil {
+			return nil, err
+		}
+		qs = append(qs, list.Questions...)
+		if !list.HasMore || len(list.Questions) == 0 {
+			break
+		}
+	}
+	if len(qs) > pickLimit {
+		qs = qs[:pickLimit]
+	}
+	if len(qs) == 0 {
+		return nil, errors.New("no questions match the filters")
+	}
+	return qs, nil
+}
+
+// readQIDFile reads qids from a file, one per line. Blank lines and lines starting with # are ignored.
+func readQIDFile(file string, c leetcode.Client) ([]*leetcode.QuestionData, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var qs []*leetcode.QuestionData
+	for _, line := range strings.Split(string(content), "\n") {
+		qid := strings.TrimSpace(line)
+		if qid == "" || strings.HasPrefix(qid, "#") {
+			continue
+		}
+		found, err := leetcode.ParseQID(qid, c)
+		if err != nil {
+			log.Error("skip invalid qid", "qid", qid, "err", err)
+			continue
+		}
+		qs = append(qs, found...)
+	}
+	if len(qs) == 0 {
+		return nil, fmt.Errorf("no questions found in %s", file)
+	}
+	return qs, nil
+}
+
+type pickOutcome struct {
+	question *leetcode.QuestionData
+	result   *lang.GenerateResult
+	lang     string
+	err      error
+}
+
+// fulfillAll fetches question details with bounded concurrency, and returns the error of each question.
+func fulfillAll(qs []*leetcode.QuestionData) []error {
+	errs := make([]error, len(qs))
+	sem := make(chan struct{}, maxFulfillWorkers)
+	var wg sync.WaitGroup
+	for i, q := range qs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, q *leetcode.QuestionData) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = q.Fulfill()
+		}(i, q)
+	}
+	wg.Wait()
+	return errs
+}
+
+func pickBatch(cmd *cobra.Command, qs []*leetcode.QuestionData) error {
+	spin := newSpinner(cmd.ErrOrStderr())
+	spin.Suffix = fmt.Sprintf(" Fetching %d questions...", len(qs))
+	spin.Start()
+	errs := fulfillAll(qs)
+	spin.Stop()
+
+	var (
+		outcomes []pickOutcome
+		ready    []*leetcode.QuestionData
+		paidOnly []string
+	)
+	for i, q := range qs {
+		switch {
+		case errors.Is(errs[i], leetcode.ErrPaidOnlyQuestion):
+			paidOnly = append(paidOnly, q.TitleSlug)
+		case errs[i] != nil:
+			outcomes = append(outcomes, pickOutcome{question: q, lang: "-", err: errs[i]})
+		default:
+			ready = append(ready, q)
+		}
+	}
+
+	err := forEachLang(
+		func(gen lang.Lang) error {
+			for _, q := range ready {
+				result, err := lang.Generate(q)
+				outcomes = append(outcomes, pickOutcome{question: q, result: result, lang: gen.Slug(), err: err})
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	w := table.NewWriter()
+	w.SetOutputMirror(cmd.OutOrStdout())
+	w.SetStyle(table.StyleColoredDark)
+	w.AppendHeader(table.Row{"Question", "Language", "Path"})
+	w.SetColumnConfigs([]table.ColumnConfig{{Number: 3, WidthMax: 80}})
+	for _, o := range outcomes {
+		title := o.question.QuestionFrontendId + ". " + o.question.GetTitle()
+		if o.err != nil {
+			w.AppendRow(table.Row{title, o.lang, "failed: " + o.err.Error()})
+			continue
+		}
+		path := "-"
+		if f := o.result.GetFile(lang.CodeFile); f != nil {
+			path = utils.RelToCwd(f.GetPath())
+		}
+		w.AppendRow(table.Row{title, o.lang, path})
+	}
+	w.Render()
+
+	if len(paidOnly) > 0 {
+		log.Warn(
+			fmt.Sprintf("skipped %d paid-only questions", len(paidOnly)),
+			"questions", strings.Join(paidOnly, ", "),
+		)
+	}
+	return nil
+}
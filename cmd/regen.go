@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/log"
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/j178/leetgo/lang"
+	"github.com/j178/leetgo/leetcode"
+	"github.com/j178/leetgo/utils"
+)
+
+var (
+	regenAll       bool
+	regenTestCases bool
+)
+
+var regenCmd = &cobra.Command{
+	Use:   "regen [qid]",
+	Short: "Regenerate question files without losing your code",
+	Long: `Regenerate question files with the current templates, blocks and modifiers.
+
+Your code between the code markers is kept, and a diff is shown before writing.
+testcases.txt is only updated with --testcases.`,
+	Example: `leetgo regen 1
+leetgo regen --all --testcases`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if regenAll {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c := leetcode.NewClient(leetcode.WithCredentials(leetcode.CredentialsFromConfig()))
+		var qs []*leetcode.QuestionData
+		if regenAll {
+			qs = generatedQuestions(c)
+			if len(qs) == 0 {
+				return errors.New("no generated questions found")
+			}
+		} else {
+			var err error
+			qs, err = leetcode.ParseQID(args[0], c)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, q := range qs {
+			err := regenQuestion(cmd, q)
+			if err != nil {
+				if !regenAll {
+					return err
+				}
+				log.Error("failed to regenerate", "question", q.TitleSlug, "err", err)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	regenCmd.Flags().BoolVar(&regenAll, "all", false, "regenerate all generated questions of the current language")
+	regenCmd.Flags().BoolVar(&regenTestCases, "testcases", false, "also update testcases.txt")
+	addVariantFlag(regenCmd)
+	addSynthesizeFlag(regenCmd)
+}
+
+// generatedQuestions returns all questions that have a solution file of the current language.
+func generatedQuestions(c leetcode.Client) []*leetcode.QuestionData {
+	var qs []*leetcode.QuestionData
+	for _, q := range leetcode.GetCache(c).GetAllQuestions() {
+		result, err := lang.GeneratePathsOnly(q)
+		if err != nil {
+			continue
+		}
+		if f := result.GetFile(lang.CodeFile); f != nil && utils.IsExist(f.GetPath()) {
+			qs = append(qs, q)
+		}
+	}
+	return qs
+}
+
+func regenQuestion(cmd *cobra.Command, q *leetcode.QuestionData) error {
+	files, err := lang.Regenerate(q, regenTestCases)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		log.Info("already up to date", "question", q.TitleSlug)
+		return nil
+	}
+
+	output := ""
+	for _, f := range files {
+		path := utils.RelToCwd(f.Path)
+		edits := myers.ComputeEdits("", f.OldContent, f.NewContent)
+		diff := gotextdiff.ToUnified(path, path, f.OldContent, edits)
+		output += "```diff\n" + fmt.Sprint(diff) + "\n```\n"
+	}
+	output, err = glamour.Render(output, "dark")
+	if err != nil {
+		return err
+	}
+	cmd.Println(output)
+	if lang.IsDryRun() {
+		return nil
+	}
+
+	accept := true
+	if !viper.GetBool("yes") {
+		err = survey.AskOne(
+			&survey.Confirm{
+				Message: fmt.Sprintf("Apply changes to %s?", q.TitleSlug),
+			}, &accept,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if !accept {
+		return nil
+	}
+	for _, f := range files {
+		err = f.Write()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
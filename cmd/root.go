@@ -5,6 +5,7 @@ import (
 	"os"
 	"runtime"
 	"runtime/debug"
+	"strings"
 
 	"github.com/charmbracelet/log"
 	cc "github.com/ivanpirog/coloredcobra"
@@ -12,6 +13,8 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/lang"
+	"github.com/j178/leetgo/leetcode"
 )
 
 var (
@@ -48,6 +51,10 @@ var rootCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		// --lang overrides code.langs in config files.
+		if f := cmd.Flags().Lookup("lang"); f != nil && f.Changed {
+			viper.Set("code.langs", []string{})
+		}
 		err = config.Load(cmd == initCmd)
 		if err != nil {
 			return fmt.Errorf(
@@ -55,10 +62,110 @@ var rootCmd = &cobra.Command{
 				err,
 			)
 		}
+		if generatesCode(cmd) {
+			err = lang.ValidateTemplates()
+			if err != nil {
+				return fmt.Errorf("invalid code template config: %w", err)
+			}
+		}
+		// --variant and --synthesize are only added to some commands, bind the ones of the running command.
+		if f := cmd.Flags().Lookup("variant"); f != nil {
+			_ = viper.BindPFlag("variant", f)
+		}
+		if f := cmd.Flags().Lookup("synthesize"); f != nil {
+			_ = viper.BindPFlag("synthesize", f)
+		}
 		return nil
 	},
 }
 
+// generatesCode reports whether the command renders code templates and runs modifiers,
+// their config is validated before such commands run, other commands don't depend on it.
+func generatesCode(cmd *cobra.Command) bool {
+	switch cmd {
+	case pickCmd, contestCmd, regenCmd, importCmd:
+		return true
+	}
+	return false
+}
+
+// forEachLang calls fn for every configured language, with code.lang switched to it,
+// so that package lang works on that language in fn.
+func forEachLang(fn func(gen lang.Lang) error) error {
+	cfg := config.Get()
+	primary := cfg.Code.Lang
+	defer func() { cfg.Code.Lang = primary }()
+
+	for _, l := range cfg.Code.AllLangs() {
+		gen, err := lang.GetGenerator(l)
+		if err != nil {
+			return err
+		}
+		cfg.Code.Lang = l
+		err = fn(gen)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// questionsFromArgs resolves the questions of commands that work on generated solutions.
+// The argument is a qid or a path; without it, the current directory is used.
+// For a path, the question, language and variant are inferred from it, and used unless set by flags.
+// For "last" and "last~N", the language it was generated in is used unless --lang is set.
+func questionsFromArgs(cmd *cobra.Command, args []string, c leetcode.Client) ([]*leetcode.QuestionData, error) {
+	cfg := config.Get()
+	langSet := cmd.Flags().Changed("lang")
+	useLang := func(slug string) {
+		if !langSet {
+			cfg.Code.Lang = slug
+			cfg.Code.Langs = nil
+		}
+	}
+
+	if len(args) == 1 && !isPathArg(args[0]) {
+		if last, isLast, _ := leetcode.ParseLastQID(args[0]); isLast && last.Gen != "" {
+			useLang(last.Gen)
+		}
+		return leetcode.ParseQID(args[0], c)
+	}
+
+	path := "."
+	if len(args) == 1 {
+		path = args[0]
+	}
+	s, err := lang.InferSolution(path, c)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("inferred question from path", "question", s.Question.TitleSlug, "lang", s.Lang.Slug(), "variant", s.Variant)
+	useLang(s.Lang.Slug())
+	if s.Variant != "" && !cmd.Flags().Changed("variant") {
+		viper.Set("variant", s.Variant)
+	}
+	return []*leetcode.QuestionData{s.Question}, nil
+}
+
+// isPathArg reports whether the argument is an existing file or directory rather than a qid.
+func isPathArg(arg string) bool {
+	info, err := os.Stat(arg)
+	if err != nil {
+		return false
+	}
+	return info.IsDir() || strings.ContainsAny(arg, `/\.`)
+}
+
+// addVariantFlag adds --variant to commands that work on a solution file.
+func addVariantFlag(cmd *cobra.Command) {
+	cmd.Flags().String("variant", "", "solution variant to work on, e.g. dp")
+}
+
+// addSynthesizeFlag adds --synthesize to commands that generate code.
+func addSynthesizeFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("synthesize", false, "build the code skeleton from question metadata instead of the LeetCode snippet")
+}
+
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
@@ -93,22 +200,30 @@ func initCommands() {
 	rootCmd.SetOut(os.Stdout)
 	rootCmd.InitDefaultVersionFlag()
 	rootCmd.Flags().SortFlags = false
-	rootCmd.PersistentFlags().StringP("lang", "l", "", "language of code to generate: cpp, go, python ...")
+	rootCmd.PersistentFlags().StringP("lang", "l", "", "language of code to generate: cpp, go, python ..., or a list like go,python3")
 	rootCmd.PersistentFlags().BoolP("yes", "y", false, "answer yes to all prompts")
+	rootCmd.PersistentFlags().Bool("dry-run", false, "print files that would be generated instead of writing them")
 	rootCmd.InitDefaultHelpFlag()
 	_ = viper.BindPFlag("code.lang", rootCmd.PersistentFlags().Lookup("lang"))
 	_ = viper.BindPFlag("yes", rootCmd.PersistentFlags().Lookup("yes"))
+	_ = viper.BindPFlag("dry-run", rootCmd.PersistentFlags().Lookup("dry-run"))
 
 	commands := []*cobra.Command{
 		initCmd,
+		doctorCmd,
 		pickCmd,
 		infoCmd,
 		testCmd,
+		complexityCmd,
 		submitCmd,
 		fixCmd,
 		editCmd,
 		extractCmd,
+		regenCmd,
+		migrateLayoutCmd,
+		importCmd,
 		contestCmd,
+		listCmd,
 		cacheCmd,
 		configCmd,
 		gitCmd,
@@ -13,49 +13,65 @@ import (
 )
 
 var submitCmd = &cobra.Command{
-	Use:   "submit qid",
+	Use:   "submit [qid | path]",
 	Short: "Submit solution",
 	Example: `leetgo submit 1
 leetgo submit two-sum
 leetgo submit last
+leetgo submit cpp/0001.two-sum.cpp
 leetgo submit w330/1
 leetgo submit w330/
 `,
 	Aliases: []string{"s"},
-	Args:    cobra.ExactArgs(1),
+	Args:    cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg := config.Get()
 		cred := leetcode.CredentialsFromConfig()
 		c := leetcode.NewClient(leetcode.WithCredentials(cred))
-		qs, err := leetcode.ParseQID(args[0], c)
+		qs, err := questionsFromArgs(cmd, args, c)
 		if err != nil {
 			return err
 		}
-		gen, err := lang.GetGenerator(cfg.Code.Lang)
+		user, err := c.GetUserStatus()
 		if err != nil {
 			return err
 		}
+		limiter := newLimiter(user)
 
-		user, err := c.GetUserStatus()
+		var reports []langReport
+		err = forEachLang(
+			func(gen lang.Lang) error {
+				for _, q := range qs {
+					log.Info("submitting solution", "question", q.TitleSlug, "lang", gen.Slug(), "user", user.Whoami(c))
+					report := langReport{Question: q, Lang: gen}
+					result, err := submitSolution(cmd, q, c, gen, limiter)
+					if err != nil {
+						log.Error("failed to submit solution", "question", q.TitleSlug, "err", err)
+						report.Submit = "Error"
+					} else {
+						cmd.Print(result.Display(q))
+						report.Submit = result.StatusMsg
+					}
+					reports = append(reports, report)
+				}
+				return nil
+			},
+		)
 		if err != nil {
 			return err
 		}
-		limiter := newLimiter(user)
-
-		for _, q := range qs {
-			log.Info("submitting solution", "question", q.TitleSlug, "user", user.Whoami(c))
-			result, err := submitSolution(cmd, q, c, gen, limiter)
-			if err != nil {
-				log.Error("failed to submit solution", "question", q.TitleSlug, "err", err)
-				continue
-			}
-			cmd.Print(result.Display(qs[0]))
+		if len(cfg.Code.AllLangs()) > 1 {
+			renderLangReports(cmd, reports)
 		}
-
 		return nil
 	},
 }
 
+func init() {
+	submitCmd.Flags().BoolVar(&forceRun, "force", false, "ignore cached results and submit again")
+	addVariantFlag(submitCmd)
+}
+
 func submitSolution(
 	cmd *cobra.Command,
 	q *leetcode.QuestionData,
@@ -70,6 +86,13 @@ func submitSolution(
 	if err != nil {
 		return nil, fmt.Errorf("failed to get solution code: %w", err)
 	}
+	normalized := lang.NormalizeCode(gen, solution)
+	if !forceRun {
+		if r := leetcode.GetCachedSubmitResult(q, gen.Slug(), normalized); r != nil {
+			log.Info("solution not changed, showing cached result", "question", q.TitleSlug, "hint", "use --force to submit again")
+			return r, nil
+		}
+	}
 
 	spin := newSpinner(cmd.ErrOrStderr())
 	spin.Suffix = " Submitting solution..."
@@ -89,5 +112,7 @@ func submitSolution(
 	if err != nil {
 		return nil, fmt.Errorf("failed to wait submit result: %w", err)
 	}
-	return testResult.(*leetcode.SubmitCheckResult), nil
+	r := testResult.(*leetcode.SubmitCheckResult)
+	leetcode.CacheSubmitResult(q, gen.Slug(), normalized, r)
+	return r, nil
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/briandowns/spinner"
 	"github.com/charmbracelet/log"
+	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 
 	"github.com/j178/leetgo/config"
@@ -22,6 +23,11 @@ var (
 	runBoth     bool
 	autoSubmit  bool
 	customCases []string
+	profile     string
+	crossCheck  bool
+	localCases  bool
+	forceRun    bool
+	allVariants bool
 )
 
 func init() {
@@ -39,19 +45,47 @@ func init() {
 		false,
 		"run test both locally and remotely",
 	)
+	testCmd.Flags().BoolVar(
+		&crossCheck,
+		"cross-check",
+		false,
+		"run cases from testcases.txt both locally and remotely, and compare the answers case by case",
+	)
+	testCmd.Flags().BoolVar(
+		&localCases,
+		"local-cases",
+		false,
+		"use cases from testcases.txt instead of the examples for remote test",
+	)
+	testCmd.Flags().BoolVar(&forceRun, "force", false, "ignore cached results and run again")
 	testCmd.Flags().StringSliceVarP(&customCases, "cases", "c", nil, "additional test cases for remote test")
 	testCmd.Flags().BoolVarP(&autoSubmit, "submit", "s", false, "auto submit if all tests passed")
+	testCmd.Flags().StringVar(&profile, "profile", "", "profile the solution when running locally: cpu or mem")
+	testCmd.Flags().BoolVar(
+		&allVariants,
+		"all-variants",
+		false,
+		"run all variants locally and compare their answers case by case",
+	)
+	addVariantFlag(testCmd)
 }
 
 var testCmd = &cobra.Command{
-	Use:     "test qid",
+	Use:     "test [qid | path]",
 	Aliases: []string{"t"},
-	Args:    cobra.ExactArgs(1),
+	Args:    cobra.MaximumNArgs(1),
 	Short:   "Run question test cases",
 	Example: `leetgo test 244
 leetgo test last
+leetgo test                           # in a question directory
+leetgo test go/0244.shortest-word-distance-ii/solution.go
 leetgo test w330/1
-leetgo test w330/`,
+leetgo test w330/
+leetgo test -L --profile cpu 244
+leetgo test --cross-check 244
+leetgo test --local-cases 244
+leetgo test -L --variant dp 322
+leetgo test -L --all-variants 322`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if runLocally {
 			runRemotely = false
@@ -60,24 +94,33 @@ leetgo test w330/`,
 			runLocally = true
 			runRemotely = true
 		}
+		if crossCheck {
+			runLocally = false
+			runRemotely = false
+		}
+		if allVariants && !runLocally {
+			return fmt.Errorf("--all-variants only works with local test")
+		}
+		var testOpts []lang.TestOption
+		if profile != "" {
+			if !runLocally && !crossCheck {
+				return fmt.Errorf("--profile only works with local test")
+			}
+			kind := lang.ProfileKind(profile)
+			if kind != lang.ProfileCPU && kind != lang.ProfileMem {
+				return fmt.Errorf("invalid profile %s, only cpu or mem is supported", profile)
+			}
+			testOpts = append(testOpts, lang.WithProfile(kind))
+		}
 
 		cfg := config.Get()
 		cred := leetcode.CredentialsFromConfig()
 		c := leetcode.NewClient(leetcode.WithCredentials(cred))
-		qs, err := leetcode.ParseQID(args[0], c)
+		qs, err := questionsFromArgs(cmd, args, c)
 		if err != nil {
 			return err
 		}
 
-		gen, err := lang.GetGenerator(cfg.Code.Lang)
-		if err != nil {
-			return err
-		}
-		_, supportLocalTest := gen.(lang.LocalTestable)
-		if runLocally && !supportLocalTest {
-			return fmt.Errorf("local test not supported for %s", cfg.Code.Lang)
-		}
-
 		user, err := c.GetUserStatus()
 		if err != nil {
 			user = &leetcode.UserStatus{}
@@ -85,40 +128,89 @@ leetgo test w330/`,
 		testLimiter := newLimiter(user)
 		submitLimiter := newLimiter(user)
 
-		for _, q := range qs {
-			localPassed, remotePassed := true, true
-			if runLocally {
-				log.Info("running test locally", "question", q.TitleSlug)
-				localPassed, err = lang.RunLocalTest(q)
-				if err != nil {
-					log.Error("failed to run test locally", "question", q.TitleSlug, "err", err)
-				}
-			}
-			if runRemotely {
-				log.Info("running test remotely", "question", q.TitleSlug, "user", user.Whoami(c))
-				result, err := runTestRemotely(cmd, q, c, gen, testLimiter)
-				if err != nil {
-					log.Error("failed to run test remotely", "question", q.TitleSlug, "err", err)
-					remotePassed = false
-				} else {
-					cmd.Print(result.Display(q))
-					remotePassed = result.CorrectAnswer
+		var reports []langReport
+		err = forEachLang(
+			func(gen lang.Lang) error {
+				_, supportLocalTest := gen.(lang.LocalTestable)
+				if (runLocally || crossCheck) && !supportLocalTest {
+					err := fmt.Errorf("local test not supported for %s", gen.Slug())
+					if len(cfg.Code.AllLangs()) == 1 {
+						return err
+					}
+					log.Error(err.Error())
+					return nil
 				}
-			}
 
-			if localPassed && remotePassed && autoSubmit {
-				result, err := submitSolution(cmd, q, c, gen, submitLimiter)
-				if err != nil {
-					log.Error("failed to submit solution", "question", q.TitleSlug, "err", err)
-				} else {
-					cmd.Print(result.Display(q))
+				for _, q := range qs {
+					report := langReport{Question: q, Lang: gen}
+					localPassed, remotePassed := true, true
+					if crossCheck {
+						log.Info("cross checking local and remote results", "question", q.TitleSlug, "lang", gen.Slug(), "user", user.Whoami(c))
+						localPassed, err = runCrossCheck(cmd, q, c, gen, testLimiter, testOpts)
+						if err != nil {
+							log.Error("failed to cross check", "question", q.TitleSlug, "err", err)
+						}
+						report.Local = passedOrFailed(localPassed && err == nil)
+					}
+					if runLocally && allVariants {
+						log.Info("comparing all variants locally", "question", q.TitleSlug, "lang", gen.Slug())
+						localPassed, err = runVariantComparison(cmd, q, testOpts)
+						if err != nil {
+							log.Error("failed to compare variants", "question", q.TitleSlug, "err", err)
+						}
+						report.Local = passedOrFailed(localPassed && err == nil)
+					} else if runLocally {
+						log.Info("running test locally", "question", q.TitleSlug, "lang", gen.Slug())
+						localPassed, err = lang.RunLocalTest(q, testOpts...)
+						if err != nil {
+							log.Error("failed to run test locally", "question", q.TitleSlug, "err", err)
+						}
+						report.Local = passedOrFailed(localPassed && err == nil)
+					}
+					if runRemotely {
+						log.Info("running test remotely", "question", q.TitleSlug, "lang", gen.Slug(), "user", user.Whoami(c))
+						result, caseNos, err := runTestRemotely(cmd, q, c, gen, testLimiter)
+						if err != nil {
+							log.Error("failed to run test remotely", "question", q.TitleSlug, "err", err)
+							remotePassed = false
+						} else {
+							cmd.Print(result.Display(q))
+							if caseNos != nil {
+								cmd.Print(formatRemoteCases(result, caseNos))
+							}
+							remotePassed = result.CorrectAnswer
+						}
+						report.Remote = passedOrFailed(remotePassed)
+					}
+
+					if localPassed && remotePassed && autoSubmit {
+						result, err := submitSolution(cmd, q, c, gen, submitLimiter)
+						if err != nil {
+							log.Error("failed to submit solution", "question", q.TitleSlug, "err", err)
+							report.Submit = "Error"
+						} else {
+							cmd.Print(result.Display(q))
+							report.Submit = result.StatusMsg
+						}
+					}
+					reports = append(reports, report)
 				}
-			}
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if len(cfg.Code.AllLangs()) > 1 {
+			renderLangReports(cmd, reports)
 		}
 		return nil
 	},
 }
 
+// runTestRemotely runs the examples or cases from testcases.txt remotely.
+// When cases from testcases.txt are used, it also returns the local case number of each remote case,
+// 0 means a custom case from flags.
 func runTestRemotely(
 	cmd *cobra.Command,
 	q *leetcode.QuestionData,
@@ -127,22 +219,136 @@ func runTestRemotely(
 	limiter *utils.RateLimiter,
 ) (
 	*leetcode.RunCheckResult,
+	[]int,
 	error,
 ) {
-	solution, err := lang.GetSolutionCode(q)
+	err := q.Fulfill()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get solution code: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch question: %s", err)
+	}
+	if !localCases {
+		cases := q.GetTestCases()
+		cases = append(cases, getCustomCases()...)
+		if len(cases) == 0 {
+			return nil, nil, fmt.Errorf("no test cases found")
+		}
+		r, err := runCodeRemotely(cmd, q, c, gen, limiter, strings.Join(cases, "\n"))
+		return r, nil, err
 	}
-	err = q.Fulfill()
+
+	cases, err := lang.GetLocalTestCases(q)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch question: %s", err)
+		return nil, nil, err
+	}
+	var (
+		inputs  []string
+		caseNos []int
+	)
+	for _, tc := range cases {
+		inputs = append(inputs, strings.Join(tc.Input, "\n"))
+		caseNos = append(caseNos, tc.No)
+	}
+	for _, custom := range getCustomCases() {
+		inputs = append(inputs, custom)
+		caseNos = append(caseNos, 0)
 	}
-	cases := q.GetTestCases()
-	cases = append(cases, getCustomCases()...)
-	if len(cases) == 0 {
-		return nil, fmt.Errorf("no test cases found")
+	if len(inputs) == 0 {
+		return nil, nil, fmt.Errorf("no test cases found")
+	}
+	r, err := runCasesRemotely(cmd, q, c, gen, limiter, inputs)
+	return r, caseNos, err
+}
+
+// runCasesRemotely runs cases in batches if there are more than LeetCode accepts in a single run.
+func runCasesRemotely(
+	cmd *cobra.Command,
+	q *leetcode.QuestionData,
+	c leetcode.Client,
+	gen lang.Lang,
+	limiter *utils.RateLimiter,
+	cases []string,
+) (
+	*leetcode.RunCheckResult,
+	error,
+) {
+	batchSize := config.Get().LeetCode.TestBatchSize()
+	var result *leetcode.RunCheckResult
+	for i := 0; i < len(cases); i += batchSize {
+		end := i + batchSize
+		if end > len(cases) {
+			end = len(cases)
+		}
+		if len(cases) > batchSize {
+			log.Info("running batch", "cases", fmt.Sprintf("%d-%d/%d", i+1, end, len(cases)))
+		}
+		r, err := runCodeRemotely(cmd, q, c, gen, limiter, strings.Join(cases[i:end], "\n"))
+		if err != nil {
+			return nil, err
+		}
+		r.PadCases(end - i)
+		if result == nil {
+			result = r
+		} else {
+			result.Merge(r)
+		}
+		// No need to run further batches if the code doesn't compile.
+		if leetcode.StatusCode(r.StatusCode) == leetcode.CompileError {
+			break
+		}
+	}
+	return result, nil
+}
+
+func formatRemoteCases(r *leetcode.RunCheckResult, caseNos []int) string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	for i, no := range caseNos {
+		name := "Custom:"
+		if no > 0 {
+			name = fmt.Sprintf("Case %d:", no)
+		}
+		if i >= len(r.CompareResult) || r.CompareResult[i] == leetcode.CaseNotRun {
+			sb.WriteString(fmt.Sprintf("%-12s-\n", name))
+			continue
+		}
+		if r.CompareResult[i] == '1' {
+			sb.WriteString(fmt.Sprintf("%-12s√\n", name))
+			continue
+		}
+		output, expected := "", ""
+		if i < len(r.CodeAnswer) {
+			output = r.CodeAnswer[i]
+		}
+		if i < len(r.ExpectedCodeAnswer) {
+			expected = r.ExpectedCodeAnswer[i]
+		}
+		sb.WriteString(fmt.Sprintf("%-12s×  Output: %s  Expected: %s\n", name, output, expected))
+	}
+	return sb.String()
+}
+
+func runCodeRemotely(
+	cmd *cobra.Command,
+	q *leetcode.QuestionData,
+	c leetcode.Client,
+	gen lang.Lang,
+	limiter *utils.RateLimiter,
+	casesStr string,
+) (
+	*leetcode.RunCheckResult,
+	error,
+) {
+	solution, err := lang.GetSolutionCode(q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get solution code: %w", err)
+	}
+	normalized := lang.NormalizeCode(gen, solution)
+	if !forceRun {
+		if r := leetcode.GetCachedRunResult(q, gen.Slug(), normalized, casesStr); r != nil {
+			log.Info("solution not changed, showing cached result", "question", q.TitleSlug, "hint", "use --force to run again")
+			return r, nil
+		}
 	}
-	casesStr := strings.Join(cases, "\n")
 
 	spin := newSpinner(cmd.ErrOrStderr())
 	spin.Suffix = " Running test..."
@@ -164,9 +370,142 @@ func runTestRemotely(
 	}
 	r := testResult.(*leetcode.RunCheckResult)
 	r.InputData = interResult.TestCase
+	leetcode.CacheRunResult(q, gen.Slug(), normalized, casesStr, r)
 	return r, nil
 }
 
+func runCrossCheck(
+	cmd *cobra.Command,
+	q *leetcode.QuestionData,
+	c leetcode.Client,
+	gen lang.Lang,
+	limiter *utils.RateLimiter,
+	testOpts []lang.TestOption,
+) (bool, error) {
+	var results []lang.CaseResult
+	opts := append([]lang.TestOption{lang.WithResults(&results)}, testOpts...)
+	localPassed, err := lang.RunLocalTest(q, opts...)
+	if err != nil {
+		return false, err
+	}
+	inputs := lang.CrossCheckInputs(results)
+	if len(inputs) == 0 {
+		return false, fmt.Errorf("no test cases found")
+	}
+	remote, err := runCasesRemotely(cmd, q, c, gen, limiter, inputs)
+	if err != nil {
+		return false, err
+	}
+	if leetcode.StatusCode(remote.StatusCode) != leetcode.Accepted {
+		cmd.Print(remote.Display(q))
+	}
+
+	w := table.NewWriter()
+	w.SetOutputMirror(cmd.OutOrStdout())
+	w.SetStyle(table.StyleColoredDark)
+	w.AppendHeader(table.Row{"Case", "Local", "Remote", "Expected", ""})
+	w.SetColumnConfigs(
+		[]table.ColumnConfig{
+			{Number: 2, WidthMax: 40},
+			{Number: 3, WidthMax: 40},
+			{Number: 4, WidthMax: 40},
+		},
+	)
+	passed := localPassed
+	for _, row := range lang.CrossCheck(q, results, remote) {
+		mark := "√"
+		if row.Mismatch {
+			mark = "×"
+			passed = false
+		}
+		w.AppendRow(table.Row{row.No, row.Local, row.Remote, row.Expected, mark})
+	}
+	w.Render()
+	return passed, nil
+}
+
+func runVariantComparison(cmd *cobra.Command, q *leetcode.QuestionData, testOpts []lang.TestOption) (bool, error) {
+	cmp, err := lang.CompareVariants(q, testOpts...)
+	if err != nil {
+		return false, err
+	}
+
+	w := table.NewWriter()
+	w.SetOutputMirror(cmd.OutOrStdout())
+	w.SetStyle(table.StyleColoredDark)
+	header := table.Row{"Case"}
+	var columns []table.ColumnConfig
+	for i, v := range cmp.Variants {
+		name := lang.VariantName(v)
+		if !cmp.Passed[i] {
+			name += " (failed)"
+		}
+		header = append(header, name)
+		columns = append(columns, table.ColumnConfig{Number: i + 2, WidthMax: 40})
+	}
+	header = append(header, "")
+	w.AppendHeader(header)
+	w.SetColumnConfigs(columns)
+	for _, r := range cmp.Rows {
+		row := table.Row{r.No}
+		for _, output := range r.Outputs {
+			row = append(row, output)
+		}
+		mark := "√"
+		if r.Mismatch {
+			mark = "×"
+		}
+		w.AppendRow(append(row, mark))
+	}
+	w.Render()
+	return cmp.AllPassed(), nil
+}
+
+// langReport is the outcome of testing or submitting a question in one language.
+type langReport struct {
+	Question *leetcode.QuestionData
+	Lang     lang.Lang
+	Local    string
+	Remote   string
+	Submit   string
+}
+
+func passedOrFailed(passed bool) string {
+	if passed {
+		return "Passed"
+	}
+	return "Failed"
+}
+
+// renderLangReports shows a summary of all languages when several languages are used.
+func renderLangReports(cmd *cobra.Command, reports []langReport) {
+	if len(reports) == 0 {
+		return
+	}
+	w := table.NewWriter()
+	w.SetOutputMirror(cmd.OutOrStdout())
+	w.SetStyle(table.StyleColoredDark)
+	w.AppendHeader(table.Row{"Question", "Language", "Local", "Remote", "Submit"})
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	for _, r := range reports {
+		w.AppendRow(
+			table.Row{
+				r.Question.QuestionFrontendId + ". " + r.Question.GetTitle(),
+				r.Lang.Name(),
+				orDash(r.Local),
+				orDash(r.Remote),
+				orDash(r.Submit),
+			},
+		)
+	}
+	w.Render()
+}
+
 func getCustomCases() []string {
 	cases := make([]string, len(customCases))
 	for i, c := range customCases {
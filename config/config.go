@@ -21,6 +21,7 @@ const (
 	ProjectConfigFilename = "leetgo.yaml"
 	questionCacheBaseName = "leetcode-questions"
 	stateFilename         = "state.json"
+	resultCacheDirname    = "results"
 	CodeBeginMarker       = "@lc code=begin"
 	CodeEndMarker         = "@lc code=end"
 	GoTestUtilsModPath    = "github.com/j178/leetgo/testutils/go"
@@ -53,8 +54,12 @@ type Config struct {
 	LeetCode    LeetCodeConfig `yaml:"leetcode" mapstructure:"leetcode" comment:"LeetCode configuration"`
 	Contest     ContestConfig  `yaml:"contest" mapstructure:"contest"`
 	Editor      Editor         `yaml:"editor" mapstructure:"editor" comment:"The editor to open generated files"`
+	Lists       QuestionLists  `yaml:"lists,omitempty" mapstructure:"lists" comment:"Your own question lists, use them as list:<name>, e.g. {mylist: [1, two-sum]}"`
 }
 
+// QuestionLists maps a list name to the qids of its questions.
+type QuestionLists map[string][]string
+
 type ContestConfig struct {
 	OutDir           string `yaml:"out_dir" mapstructure:"out_dir" comment:"Base dir to put generated contest questions"`
 	FilenameTemplate string `yaml:"filename_template" mapstructure:"filename_template" comment:"Template to generate filename of the question"`
@@ -68,21 +73,31 @@ type Editor struct {
 }
 
 type Block struct {
-	Name     string `yaml:"name" mapstructure:"name"`
-	Template string `yaml:"template" mapstructure:"template"`
+	Name         string `yaml:"name" mapstructure:"name"`
+	Template     string `yaml:"template,omitempty" mapstructure:"template"`
+	TemplateFile string `yaml:"template_file,omitempty" mapstructure:"template_file" comment:"Read the template from a file, relative to the project root"`
 }
 
 type Modifier struct {
-	Name   string `yaml:"name" mapstructure:"name"`
-	Script string `yaml:"script,omitempty" mapstructure:"script"`
+	Name       string   `yaml:"name" mapstructure:"name"`
+	Script     string   `yaml:"script,omitempty" mapstructure:"script"`
+	ScriptFile string   `yaml:"script_file,omitempty" mapstructure:"script_file" comment:"Read the script from a file, relative to the project root"`
+	Command    []string `yaml:"command,omitempty" mapstructure:"command" comment:"Command that reads code from stdin and writes the modified code to stdout"`
+	Timeout    string   `yaml:"timeout,omitempty" mapstructure:"timeout" comment:"Timeout of the command, e.g. 10s"`
 }
 
 type CodeConfig struct {
 	Lang                    string         `yaml:"lang" mapstructure:"lang" comment:"Language of code generated for questions: go, python, ... \n(will be override by project config and flag --lang)"`
+	Langs                   []string       `yaml:"langs,omitempty" mapstructure:"langs" comment:"Generate, test and submit in several languages at once, e.g. [go, python3], overrides lang"`
 	FilenameTemplate        string         `yaml:"filename_template" mapstructure:"filename_template" comment:"The default template to generate filename (without extension), e.g. {{.Id}}.{{.Slug}}\nAvailable attributes: Id, Slug, Title, Difficulty, Lang, SlugIsMeaningful\nAvailable functions: lower, upper, trim, padWithZero, toUnderscore"`
 	SeparateDescriptionFile bool           `yaml:"separate_description_file" mapstructure:"separate_description_file" comment:"Generate question description into a separate file"`
+	DescriptionSections     []string       `yaml:"description_sections,omitempty" mapstructure:"description_sections" comment:"Extra sections of the question description: tags, hints, similar_questions, stats"`
+	DownloadImages          bool           `yaml:"download_images,omitempty" mapstructure:"download_images" comment:"Download images of the question description into an assets folder and link them locally"`
+	Template                string         `yaml:"template,omitempty" mapstructure:"template" comment:"Replace the whole template of the generated code"`
+	TemplateFile            string         `yaml:"template_file,omitempty" mapstructure:"template_file" comment:"Read the whole template of the generated code from a file, relative to the project root"`
 	Blocks                  []Block        `yaml:"blocks,omitempty" mapstructure:"blocks" comment:"Replace some blocks of the generated code"`
 	Modifiers               []Modifier     `yaml:"modifiers,omitempty" mapstructure:"modifiers" comment:"Functions that modify the generated code"`
+	CustomLangs             []CustomLang   `yaml:"custom_langs,omitempty" mapstructure:"custom_langs" comment:"Languages defined by yourself"`
 	Go                      GoConfig       `yaml:"go" mapstructure:"go"`
 	Python                  BaseLangConfig `yaml:"python3" mapstructure:"python3"`
 	Cpp                     BaseLangConfig `yaml:"cpp" mapstructure:"cpp"`
@@ -95,10 +110,28 @@ type BaseLangConfig struct {
 	OutDir                  string     `yaml:"out_dir" mapstructure:"out_dir"`
 	FilenameTemplate        string     `yaml:"filename_template" mapstructure:"filename_template" comment:"Overrides the default code.filename_template"`
 	SeparateDescriptionFile bool       `yaml:"separate_description_file,omitempty" mapstructure:"separate_description_file" comment:"Generate question description into a separate file"`
+	Template                string     `yaml:"template,omitempty" mapstructure:"template" comment:"Overrides the default code.template"`
+	TemplateFile            string     `yaml:"template_file,omitempty" mapstructure:"template_file" comment:"Overrides the default code.template_file"`
 	Blocks                  []Block    `yaml:"blocks,omitempty" mapstructure:"blocks" comment:"Replace some blocks of the generated code"`
 	Modifiers               []Modifier `yaml:"modifiers,omitempty" mapstructure:"modifiers" comment:"Functions that modify the generated code"`
 }
 
+// CustomLang declares a language that is not supported by leetgo yet.
+// Build, Run and HarnessTemplate are needed only for local test.
+type CustomLang struct {
+	Name              string   `yaml:"name" mapstructure:"name" comment:"Name of the language"`
+	Slug              string   `yaml:"slug" mapstructure:"slug" comment:"Language slug used by LeetCode, e.g. scala, elixir, racket, dart"`
+	ShortName         string   `yaml:"short_name" mapstructure:"short_name"`
+	Extension         string   `yaml:"extension" mapstructure:"extension" comment:"Extension of the code file, e.g. .scala"`
+	LineComment       string   `yaml:"line_comment" mapstructure:"line_comment"`
+	BlockCommentStart string   `yaml:"block_comment_start" mapstructure:"block_comment_start"`
+	BlockCommentEnd   string   `yaml:"block_comment_end" mapstructure:"block_comment_end"`
+	Build             []string `yaml:"build,omitempty" mapstructure:"build" comment:"Command to build the solution, available attributes: Dir, File"`
+	Run               []string `yaml:"run,omitempty" mapstructure:"run" comment:"Command to run the solution, available attributes: Dir, File"`
+	HeaderTemplate    string   `yaml:"header_template,omitempty" mapstructure:"header_template" comment:"Template of code put before the solution, e.g. imports"`
+	HarnessTemplate   string   `yaml:"harness_template,omitempty" mapstructure:"harness_template" comment:"Template of code that reads input from stdin, calls the solution and prints the output"`
+}
+
 type GoConfig struct {
 	BaseLangConfig `yaml:",inline" mapstructure:",squash"`
 }
@@ -112,8 +145,21 @@ type Credentials struct {
 }
 
 type LeetCodeConfig struct {
-	Site        LeetcodeSite `yaml:"site" mapstructure:"site" comment:"LeetCode site, https://leetcode.com or https://leetcode.cn"`
-	Credentials Credentials  `yaml:"credentials" mapstructure:"credentials" comment:"Credentials to access LeetCode"`
+	Site                LeetcodeSite `yaml:"site" mapstructure:"site" comment:"LeetCode site, https://leetcode.com or https://leetcode.cn"`
+	Credentials         Credentials  `yaml:"credentials" mapstructure:"credentials" comment:"Credentials to access LeetCode"`
+	RemoteTestBatchSize int          `yaml:"remote_test_batch_size" mapstructure:"remote_test_batch_size" comment:"Maximum number of test cases sent in a single remote test run, more cases are run in batches"`
+}
+
+// defaultRemoteTestBatchSize is a conservative number of cases per remote run,
+// LeetCode rejects runs with too many cases and doesn't document the limit.
+const defaultRemoteTestBatchSize = 10
+
+// TestBatchSize returns the configured remote test batch size, or the default if it's not positive.
+func (c LeetCodeConfig) TestBatchSize() int {
+	if c.RemoteTestBatchSize <= 0 {
+		return defaultRemoteTestBatchSize
+	}
+	return c.RemoteTestBatchSize
 }
 
 func (c *Config) ConfigDir() string {
@@ -160,6 +206,10 @@ func (c *Config) StateFile() string {
 	return filepath.Join(c.CacheDir(), stateFilename)
 }
 
+func (c *Config) ResultCacheDir() string {
+	return filepath.Join(c.CacheDir(), resultCacheDirname)
+}
+
 func (c *Config) QuestionCacheFile(ext string) string {
 	return filepath.Join(c.CacheDir(), questionCacheBaseName+ext)
 }
@@ -211,6 +261,7 @@ func Default() *Config {
 			Credentials: Credentials{
 				From: "browser",
 			},
+			RemoteTestBatchSize: defaultRemoteTestBatchSize,
 		},
 		Editor: Editor{
 			Use: "none",
@@ -230,6 +281,30 @@ func Get() *Config {
 	return globalCfg
 }
 
+// resolveLangs splits a comma separated lang like "go,python3" into Langs,
+// and makes the first one of Langs the primary language used by single-language commands.
+func (c *CodeConfig) resolveLangs() {
+	if strings.Contains(c.Lang, ",") {
+		c.Langs = nil
+		for _, l := range strings.Split(c.Lang, ",") {
+			if l = strings.TrimSpace(l); l != "" {
+				c.Langs = append(c.Langs, l)
+			}
+		}
+	}
+	if len(c.Langs) > 0 {
+		c.Lang = c.Langs[0]
+	}
+}
+
+// AllLangs returns all languages to generate code for, the primary language comes first.
+func (c CodeConfig) AllLangs() []string {
+	if len(c.Langs) > 0 {
+		return c.Langs
+	}
+	return []string{c.Lang}
+}
+
 func verify(c *Config) error {
 	if c.Language != ZH && c.Language != EN {
 		return fmt.Errorf("invalid language: %s", c.Language)
@@ -240,6 +315,14 @@ func verify(c *Config) error {
 	if c.LeetCode.Site != LeetCodeCN && c.LeetCode.Site != LeetCodeUS {
 		return fmt.Errorf("invalid leetcode.site: %s", c.LeetCode.Site)
 	}
+	for _, l := range c.Code.CustomLangs {
+		if l.Name == "" || l.Slug == "" || l.Extension == "" {
+			return fmt.Errorf("code.custom_langs: name, slug and extension are required")
+		}
+		if len(l.Run) > 0 && l.HarnessTemplate == "" {
+			return fmt.Errorf("code.custom_langs: harness_template is required for %s to run local test", l.Name)
+		}
+	}
 	credentialFrom := map[string]bool{
 		"browser":  true,
 		"cookies":  true,
@@ -338,6 +421,7 @@ func Load(init bool) error {
 	if err != nil {
 		return fmt.Errorf("config file is invalid: %s", err)
 	}
+	cfg.Code.resolveLangs()
 	if err = verify(cfg); err != nil {
 		return fmt.Errorf("config file is invalid: %w", err)
 	}
@@ -18,8 +18,36 @@ type LastQuestion struct {
 }
 
 type State struct {
-	LastQuestion LastQuestion `json:"last_question"`
-	LastContest  string       `json:"last_contest"`
+	LastQuestion LastQuestion   `json:"last_question"`
+	History      []LastQuestion `json:"history,omitempty"`
+	LastContest  string         `json:"last_contest"`
+}
+
+// maxHistory is the number of generated questions kept in the history, for "last~N".
+const maxHistory = 20
+
+// SetLastQuestion records q as the last generated question, and pushes it to the front of the history.
+func (s *State) SetLastQuestion(q LastQuestion) {
+	s.LastQuestion = q
+	history := []LastQuestion{q}
+	for _, h := range s.History {
+		if h.Slug != q.Slug && len(history) < maxHistory {
+			history = append(history, h)
+		}
+	}
+	s.History = history
+}
+
+// RecentQuestion returns the question generated n questions before the last one, 0 is the last one.
+func (s State) RecentQuestion(n int) (LastQuestion, bool) {
+	history := s.History
+	if len(history) == 0 && s.LastQuestion.Slug != "" {
+		history = []LastQuestion{s.LastQuestion}
+	}
+	if n < 0 || n >= len(history) {
+		return LastQuestion{}, false
+	}
+	return history[n], true
 }
 
 type States map[string]State
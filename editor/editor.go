@@ -12,12 +12,12 @@ import (
 )
 
 type Opener interface {
-	Open(result *lang.GenerateResult) error
+	Open(results ...*lang.GenerateResult) error
 }
 
 type MultiOpener interface {
 	Opener
-	OpenMulti(result *lang.GenerateResult) error
+	OpenMulti(results ...*lang.GenerateResult) error
 }
 
 var editors = map[string]Opener{
@@ -41,7 +41,7 @@ var editors = map[string]Opener{
 
 type noneEditor struct{}
 
-func (e *noneEditor) Open(result *lang.GenerateResult) error {
+func (e *noneEditor) Open(results ...*lang.GenerateResult) error {
 	log.Info("none editor is used, skip opening files")
 	return nil
 }
@@ -51,19 +51,21 @@ type commonEditor struct {
 	args    []string
 }
 
-func (e *commonEditor) Open(result *lang.GenerateResult) error {
+func (e *commonEditor) Open(results ...*lang.GenerateResult) error {
 	log.Info("opening file", "command", e.command)
-	return runCmd(e.command, e.args, result.GetFile(lang.CodeFile).GetPath())
+	return runCmd(e.command, e.args, codeFiles(results)...)
 }
 
 type commonMultiEditor struct {
 	commonEditor
 }
 
-func (e *commonMultiEditor) OpenMulti(result *lang.GenerateResult) error {
-	paths := make([]string, 0, len(result.Files))
-	for _, f := range result.Files {
-		paths = append(paths, f.GetPath())
+func (e *commonMultiEditor) OpenMulti(results ...*lang.GenerateResult) error {
+	var paths []string
+	for _, result := range results {
+		for _, f := range result.Files {
+			paths = append(paths, f.GetPath())
+		}
 	}
 	log.Info("opening files", "command", e.command)
 	return runCmd(e.command, e.args, paths...)
@@ -71,26 +73,43 @@ func (e *commonMultiEditor) OpenMulti(result *lang.GenerateResult) error {
 
 type customEditor struct{}
 
-func (e *customEditor) Open(result *lang.GenerateResult) error {
+func (e *customEditor) Open(results ...*lang.GenerateResult) error {
 	cfg := config.Get()
 	if cfg.Editor.Command == "" {
 		log.Warn("editor.command is empty, skip opening files")
 		return nil
 	}
 	log.Info("opening files", "command", cfg.Editor.Command)
-	return runCmd(cfg.Editor.Command, cfg.Editor.Args, result.GetFile(lang.CodeFile).GetPath())
+	return runCmd(cfg.Editor.Command, cfg.Editor.Args, codeFiles(results)...)
+}
+
+// codeFiles returns the code file of every result.
+func codeFiles(results []*lang.GenerateResult) []string {
+	paths := make([]string, 0, len(results))
+	for _, r := range results {
+		paths = append(paths, r.GetFile(lang.CodeFile).GetPath())
+	}
+	return paths
 }
 
 func Get(s string) Opener {
 	return editors[s]
 }
 
-func Open(result *lang.GenerateResult) error {
-	if len(result.Files) == 0 {
-		return nil
+// Open opens the generated files of one question, possibly in several languages.
+func Open(results ...*lang.GenerateResult) error {
+	var nonEmpty []*lang.GenerateResult
+	for _, result := range results {
+		if len(result.Files) == 0 {
+			continue
+		}
+		if result.GetFile(lang.CodeFile) == nil {
+			return fmt.Errorf("no code file found")
+		}
+		nonEmpty = append(nonEmpty, result)
 	}
-	if result.GetFile(lang.CodeFile) == nil {
-		return fmt.Errorf("no code file found")
+	if len(nonEmpty) == 0 {
+		return nil
 	}
 
 	cfg := config.Get()
@@ -102,9 +121,9 @@ func Open(result *lang.GenerateResult) error {
 		)
 	}
 	if ed, ok := ed.(MultiOpener); ok {
-		return ed.OpenMulti(result)
+		return ed.OpenMulti(nonEmpty...)
 	}
-	return ed.Open(result)
+	return ed.Open(nonEmpty...)
 }
 
 func runCmd(command string, args []string, files ...string) error {
@@ -8,10 +8,8 @@ import (
 	"path/filepath"
 	"strings"
 	"text/template"
-	"time"
 
 	"github.com/charmbracelet/log"
-	"github.com/dop251/goja"
 	"github.com/spf13/viper"
 
 	"github.com/j178/leetgo/config"
@@ -30,6 +28,7 @@ type GenerateResult struct {
 	Lang     Lang
 	OutDir   string
 	SubDir   string
+	Variant  string
 	Files    []FileOutput
 	mask     int
 }
@@ -105,7 +104,28 @@ type NeedInitialization interface {
 }
 
 type LocalTestable interface {
-	RunLocalTest(q *leetcode.QuestionData, dir string) (bool, error)
+	RunLocalTest(q *leetcode.QuestionData, dir string, opts ...TestOption) (bool, error)
+}
+
+// Buildable is implemented by languages that can build the solution into an executable,
+// so that it can be run many times without rebuilding.
+type Buildable interface {
+	// Build builds the solution into buildDir and returns the command to run it.
+	Build(q *leetcode.QuestionData, outDir string, buildDir string) ([]string, error)
+}
+
+// Profiler is implemented by languages that can profile the solution during local test.
+type Profiler interface {
+	// ProfileEnv returns environment variables that make the solution write a profile of kind into file.
+	ProfileEnv(kind ProfileKind, file string) []string
+	// ProfileReport returns the command that prints a summary of the profile file.
+	ProfileReport(kind ProfileKind, file string) []string
+}
+
+// Timer is implemented by languages whose solution can report how long the solution call takes.
+type Timer interface {
+	// TimingEnv returns environment variables that make the solution write the elapsed nanoseconds into file.
+	TimingEnv(file string) []string
 }
 
 func getCodeStringConfig(lang Lang, key string) string {
@@ -131,14 +151,17 @@ func getOutDir(q *leetcode.QuestionData, lang Lang) string {
 	if q.IsContest() {
 		return config.Get().Contest.OutDir
 	}
-	cfg := config.Get()
+	return langOutDir(lang, config.Get().ProjectRoot())
+}
+
+// langOutDir returns the out_dir of the language under the project root.
+func langOutDir(lang Lang, projectRoot string) string {
 	outDir := getCodeStringConfig(lang, "out_dir")
 	// If outDir is not set, use the language slug as the outDir.
 	if outDir == "" {
 		outDir = lang.Slug()
 	}
-	outDir = filepath.Join(cfg.ProjectRoot(), outDir)
-	return outDir
+	return filepath.Join(projectRoot, outDir)
 }
 
 func separateDescriptionFile(lang Lang) bool {
@@ -165,6 +188,10 @@ const codeContentTemplate = `
 {{ .BlockCommentStart }}
 {{ block "title" . }}{{ .Question.QuestionFrontendId }}. {{ .Question.GetTitle }} ({{ .Question.Difficulty }}){{ end }}
 {{ .Question.GetFormattedContent }}
+{{- block "tags" . }}{{ .Sections.Tags }}{{ end }}
+{{- block "hints" . }}{{ .Sections.Hints }}{{ end }}
+{{- block "similarQuestions" . }}{{ .Sections.SimilarQuestions }}{{ end }}
+{{- block "stats" . }}{{ .Sections.Stats }}{{ end }}
 {{ .BlockCommentEnd }}
 {{ end }}
 {{ end }}
@@ -191,17 +218,23 @@ type codeContentData struct {
 	Code                    string
 	SeparateDescriptionFile bool
 	NeedsDefinition         bool
+	Sections                descriptionSections
+	leetcode.TemplateData
 }
 
 var validBlocks = map[string]bool{
-	"header":       true,
-	"description":  true,
-	"title":        true,
-	"beforeMarker": true,
-	"beforeCode":   true,
-	"code":         true,
-	"afterCode":    true,
-	"afterMarker":  true,
+	"header":           true,
+	"description":      true,
+	"title":            true,
+	"tags":             true,
+	"hints":            true,
+	"similarQuestions": true,
+	"stats":            true,
+	"beforeMarker":     true,
+	"beforeCode":       true,
+	"code":             true,
+	"afterCode":        true,
+	"afterMarker":      true,
 }
 
 // internal blocks are used to generate code for internal use.
@@ -219,9 +252,44 @@ var builtinModifiers = map[string]ModifierFunc{
 	"removeUselessComments": removeUselessComments,
 }
 
-type ModifierFunc = func(string, *leetcode.QuestionData) string
+type ModifierFunc = func(string, *leetcode.QuestionData) (string, error)
+
+// stringField returns the string value of key in a raw config map.
+func stringField(m map[string]any, key string) string {
+	if v, ok := m[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// readProjectFile reads a template or script file, relative paths are resolved against the project root.
+func readProjectFile(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(config.Get().ProjectRoot(), path)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
 
-func getBlocks(lang Lang) (ans []config.Block) {
+// inlineOrFile returns the inline content, or the content of file if it is set.
+func inlineOrFile(inline string, file string, what string) (string, error) {
+	if inline != "" && file != "" {
+		return "", fmt.Errorf("%s and %s_file cannot be set at the same time", what, what)
+	}
+	if file == "" {
+		return inline, nil
+	}
+	content, err := readProjectFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s_file: %w", what, err)
+	}
+	return content, nil
+}
+
+func getBlocks(lang Lang) (ans []config.Block, err error) {
 	blocks := viper.Get("code." + lang.Slug() + ".blocks")
 	if blocks == nil || len(blocks.([]any)) == 0 {
 		blocks = viper.Get("code." + lang.ShortName() + ".blocks")
@@ -233,16 +301,62 @@ func getBlocks(lang Lang) (ans []config.Block) {
 		return
 	}
 	for _, b := range blocks.([]any) {
-		ans = append(
-			ans, config.Block{
-				Name:     b.(map[string]any)["name"].(string),
-				Template: b.(map[string]any)["template"].(string),
-			},
-		)
+		b, _ := b.(map[string]any)
+		block := config.Block{
+			Name:         stringField(b, "name"),
+			TemplateFile: stringField(b, "template_file"),
+		}
+		block.Template, err = inlineOrFile(stringField(b, "template"), block.TemplateFile, "template")
+		if err != nil {
+			return nil, fmt.Errorf("block %q: %w", block.Name, err)
+		}
+		ans = append(ans, block)
 	}
 	return
 }
 
+// getRootTemplate returns the template of the whole code file, codeContentTemplate is used if not configured.
+func getRootTemplate(lang Lang) (string, error) {
+	inline := getCodeStringConfig(lang, "template")
+	file := getCodeStringConfig(lang, "template_file")
+	if inline == "" && file == "" {
+		inline = viper.GetString("code.template")
+		file = viper.GetString("code.template_file")
+	}
+	tmpl, err := inlineOrFile(inline, file, "template")
+	if err != nil {
+		return "", err
+	}
+	if tmpl == "" {
+		return codeContentTemplate, nil
+	}
+	return tmpl, nil
+}
+
+// newCodeTemplate parses the root template and blocks that override parts of it.
+func newCodeTemplate(root string, blocks []config.Block, funcs template.FuncMap) (*template.Template, error) {
+	tmpl := template.New("root")
+	tmpl.Funcs(utils.TemplateFuncs())
+	tmpl.Funcs(funcs)
+	_, err := tmpl.Parse(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid code template: %w", err)
+	}
+	for _, block := range blocks {
+		if !validBlocks[block.Name] && !internalBlocks[block.Name] {
+			return nil, fmt.Errorf("invalid block name: %q", block.Name)
+		}
+		_, err := tmpl.New(block.Name).Parse(block.Template)
+		if err != nil {
+			if block.TemplateFile != "" {
+				return nil, fmt.Errorf("invalid template of block %q in %s: %w", block.Name, block.TemplateFile, err)
+			}
+			return nil, fmt.Errorf("invalid template of block %q: %w", block.Name, err)
+		}
+	}
+	return tmpl, nil
+}
+
 func getModifiers(lang Lang, modifiersMap map[string]ModifierFunc) ([]ModifierFunc, error) {
 	modifiers := viper.Get("code." + lang.Slug() + ".modifiers")
 	if modifiers == nil || len(modifiers.([]any)) == 0 {
@@ -256,39 +370,39 @@ func getModifiers(lang Lang, modifiersMap map[string]ModifierFunc) ([]ModifierFu
 	}
 
 	var funcs []ModifierFunc
-	for _, m := range modifiers.([]any) {
-		m := m.(map[string]any)
-		name, script := "", ""
-
-		if m["name"] != nil {
-			name = m["name"].(string)
-			if f, ok := modifiersMap[name]; ok {
-				funcs = append(funcs, f)
-				continue
-			}
+	for i, m := range modifiers.([]any) {
+		m, _ := m.(map[string]any)
+		name := stringField(m, "name")
+		if f, ok := modifiersMap[name]; ok {
+			funcs = append(funcs, f)
+			continue
 		}
-		if m["script"] != nil {
-			script = m["script"].(string)
-			vm := goja.New()
-			_, err := vm.RunString(script)
+		if m["command"] != nil {
+			f, err := getCommandModifier(m, lang)
 			if err != nil {
-				return nil, fmt.Errorf("failed to run script: %w", err)
+				return nil, fmt.Errorf("modifier %d: %w", i+1, err)
 			}
-			var jsFn func(string) string
-			if vm.Get("modify") == nil {
-				return nil, fmt.Errorf("failed to get modify function")
+			funcs = append(funcs, f)
+			continue
+		}
+		scriptFile := stringField(m, "script_file")
+		script, err := inlineOrFile(stringField(m, "script"), scriptFile, "script")
+		if err != nil {
+			return nil, fmt.Errorf("modifier %d: %w", i+1, err)
+		}
+		if script != "" {
+			scriptName := scriptFile
+			if scriptName == "" {
+				scriptName = fmt.Sprintf("modifier#%d", i+1)
 			}
-			err = vm.ExportTo(vm.Get("modify"), &jsFn)
+			f, err := compileModifierScript(scriptName, script, lang)
 			if err != nil {
-				return nil, fmt.Errorf("failed to export function: %w", err)
-			}
-			f := func(s string, data *leetcode.QuestionData) string {
-				return jsFn(s)
+				return nil, err
 			}
 			funcs = append(funcs, f)
 			continue
 		}
-		log.Warn("invalid modifier, ignored", "name", name, "script", script)
+		log.Warn("invalid modifier, ignored", "name", name)
 	}
 	return funcs, nil
 }
@@ -301,7 +415,7 @@ func needsMod(content string) bool {
 	return strings.Contains(content, "<code>10<sup>9</sup> + 7</code>") || strings.Contains(content, "10^9 + 7")
 }
 
-func removeUselessComments(code string, q *leetcode.QuestionData) string {
+func removeUselessComments(code string, q *leetcode.QuestionData) (string, error) {
 	lines := strings.Split(code, "\n")
 	var newLines []string
 	for i := 0; i < len(lines); i++ {
@@ -320,6 +434,70 @@ func removeUselessComments(code string, q *leetcode.QuestionData) string {
 		}
 		newLines = append(newLines, line)
 	}
+	return strings.Join(newLines, "\n"), nil
+}
+
+// stripComments removes comments from code, comment markers inside string literals are kept.
+func stripComments(code string, lineComment string, blockStart string, blockEnd string) string {
+	var sb strings.Builder
+	var quote byte
+	for i := 0; i < len(code); {
+		c := code[i]
+		if quote != 0 {
+			sb.WriteByte(c)
+			switch {
+			case c == '\\' && i+1 < len(code):
+				sb.WriteByte(code[i+1])
+				i++
+			case c == quote, c == '\n' && quote != '`':
+				quote = 0
+			}
+			i++
+			continue
+		}
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case lineComment != "" && strings.HasPrefix(code[i:], lineComment):
+			end := strings.IndexByte(code[i:], '\n')
+			if end < 0 {
+				return sb.String()
+			}
+			i += end
+			continue
+		case blockStart != "" && strings.HasPrefix(code[i:], blockStart):
+			end := strings.Index(code[i+len(blockStart):], blockEnd)
+			if end < 0 {
+				return sb.String()
+			}
+			i += len(blockStart) + end + len(blockEnd)
+			continue
+		}
+		sb.WriteByte(c)
+		i++
+	}
+	return sb.String()
+}
+
+type commenter interface {
+	commentSyntax() (lineComment string, blockStart string, blockEnd string)
+}
+
+// NormalizeCode removes comments and blank lines from code,
+// so that code differs only in comments or blank lines are considered the same.
+func NormalizeCode(l Lang, code string) string {
+	if b, ok := l.(commenter); ok {
+		lineComment, blockStart, blockEnd := b.commentSyntax()
+		code = stripComments(code, lineComment, blockStart, blockEnd)
+	}
+	lines := strings.Split(code, "\n")
+	newLines := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line != "" {
+			newLines = append(newLines, line)
+		}
+	}
 	return strings.Join(newLines, "\n")
 }
 
@@ -345,6 +523,14 @@ func (l baseLang) ShortName() string {
 	return l.shortName
 }
 
+func (l baseLang) codeExtension() string {
+	return l.extension
+}
+
+func (l baseLang) commentSyntax() (string, string, string) {
+	return l.lineComment, l.blockCommentStart, l.blockCommentEnd
+}
+
 func (l baseLang) generateCodeContent(
 	q *leetcode.QuestionData,
 	blocks []config.Block,
@@ -352,36 +538,47 @@ func (l baseLang) generateCodeContent(
 	separateDescriptionFile bool,
 ) (string, error) {
 	code := q.GetCodeSnippet(l.Slug())
-	tmpl := template.New("root")
-	tmpl.Funcs(
-		template.FuncMap{
-			"runModifiers": func(code string) string {
+	root, err := getRootTemplate(l)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := newCodeTemplate(
+		root, blocks, template.FuncMap{
+			"runModifiers": func(code string) (string, error) {
+				var err error
 				for _, m := range modifiers {
-					code = m(code, q)
+					code, err = m(code, q)
+					if err != nil {
+						return "", err
+					}
 				}
-				return code
+				return code, nil
+			},
+			// comment turns text into line comments of the language.
+			"comment": func(s string) string {
+				return utils.PrefixLines(l.lineComment+" ", s)
 			},
 		},
 	)
-	_, err := tmpl.Parse(codeContentTemplate)
 	if err != nil {
 		return "", err
 	}
-	for _, block := range blocks {
-		if !validBlocks[block.Name] && !internalBlocks[block.Name] {
-			return "", fmt.Errorf("invalid block name: %s", block.Name)
-		}
-		_, err := tmpl.New(block.Name).Parse(block.Template)
+
+	var sections descriptionSections
+	if !separateDescriptionFile {
+		names, err := getDescriptionSections()
 		if err != nil {
 			return "", err
 		}
+		sections = commentSections(q, l.slug, names)
 	}
 
 	cfg := config.Get()
+	tmplData := q.TemplateData()
 	data := &codeContentData{
 		Question:                q,
 		Author:                  cfg.Author,
-		Time:                    time.Now().Format("2006/01/02 15:04"),
+		Time:                    tmplData.Date.Format("2006/01/02 15:04"),
 		LineComment:             l.lineComment,
 		BlockCommentStart:       l.blockCommentStart,
 		BlockCommentEnd:         l.blockCommentEnd,
@@ -390,6 +587,8 @@ func (l baseLang) generateCodeContent(
 		Code:                    code,
 		SeparateDescriptionFile: separateDescriptionFile,
 		NeedsDefinition:         needsDefinition(code),
+		Sections:                sections,
+		TemplateData:            tmplData,
 	}
 	var buf bytes.Buffer
 	err = tmpl.Execute(&buf, data)
@@ -462,7 +661,8 @@ func (l baseLang) generateTestFile(q *leetcode.QuestionData, filename string) (F
 	return FileOutput{}, errors.New("not implemented")
 }
 
-func (l baseLang) generateDescriptionFile(q *leetcode.QuestionData, filename string) (FileOutput, error) {
+// generateDescriptionFile generates the description file, subDir is the SubDir of the GenerateResult it belongs to.
+func (l baseLang) generateDescriptionFile(q *leetcode.QuestionData, subDir string, filename string) (FileOutput, error) {
 	tmpl := `# [%s. %s](%s) (%s)
 %s`
 	url := ""
@@ -479,6 +679,15 @@ func (l baseLang) generateDescriptionFile(q *leetcode.QuestionData, filename str
 		q.Difficulty,
 		q.GetFormattedContent(),
 	)
+
+	names, err := getDescriptionSections()
+	if err != nil {
+		return FileOutput{}, err
+	}
+	if len(names) > 0 {
+		dir := filepath.Join(getOutDir(q, l), subDir, filepath.Dir(filename))
+		content = utils.EnsureTrailingNewline(content) + markdownSections(q, l.slug, names, dir)
+	}
 	return FileOutput{
 		Filename: filename,
 		Content:  content,
@@ -515,6 +724,11 @@ func (l baseLang) GeneratePaths(q *leetcode.QuestionData) (*GenerateResult, erro
 }
 
 func (l baseLang) Generate(q *leetcode.QuestionData) (*GenerateResult, error) {
+	return l.generateFiles(q)
+}
+
+// generateFiles generates code and description files, internal blocks are appended to the configured blocks.
+func (l baseLang) generateFiles(q *leetcode.QuestionData, internal ...config.Block) (*GenerateResult, error) {
 	filenameTmpl := getFilenameTemplate(q, l)
 	baseFilename, err := q.GetFormattedFilename(l.slug, filenameTmpl)
 	if err != nil {
@@ -527,7 +741,11 @@ func (l baseLang) Generate(q *leetcode.QuestionData) (*GenerateResult, error) {
 	}
 
 	separateDescriptionFile := separateDescriptionFile(l)
-	blocks := getBlocks(l)
+	blocks, err := getBlocks(l)
+	if err != nil {
+		return nil, err
+	}
+	blocks = append(blocks, internal...)
 	modifiers, err := getModifiers(l, builtinModifiers)
 	if err != nil {
 		return nil, err
@@ -539,7 +757,7 @@ func (l baseLang) Generate(q *leetcode.QuestionData) (*GenerateResult, error) {
 	genResult.AddFile(codeFile)
 
 	if separateDescriptionFile {
-		docFile, err := l.generateDescriptionFile(q, baseFilename+".md")
+		docFile, err := l.generateDescriptionFile(q, "", baseFilename+".md")
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,118 @@
+package lang
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/leetcode"
+)
+
+// defaultCommandTimeout is how long a command modifier may run if timeout is not configured.
+const defaultCommandTimeout = 10 * time.Second
+
+// Command modifiers talk to the external process with a simple protocol:
+// code is written to stdin, the question context is passed as JSON in the file $LEETGO_QUESTION_FILE,
+// the modified code is read from stdout. The environment only carries short values,
+// the question can be too large for it, e.g. Windows limits the size of the environment.
+const (
+	envQuestionFile = "LEETGO_QUESTION_FILE"
+	envQuestionSlug = "LEETGO_QUESTION_SLUG"
+	envQuestionID   = "LEETGO_QUESTION_ID"
+	envLang         = "LEETGO_LANG"
+)
+
+// commandArgs returns the command of a modifier, it can be a list or a string split by spaces.
+func commandArgs(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		args := make([]string, 0, len(v))
+		for _, a := range v {
+			args = append(args, fmt.Sprint(a))
+		}
+		return args
+	case []string:
+		return v
+	}
+	return nil
+}
+
+func newCommandModifier(args []string, timeout time.Duration, lang Lang) ModifierFunc {
+	name := strings.Join(args, " ")
+	return func(code string, q *leetcode.QuestionData) (string, error) {
+		data, err := json.Marshal(newScriptQuestion(q, lang))
+		if err != nil {
+			return "", err
+		}
+		f, err := os.CreateTemp("", "leetgo-question-*.json")
+		if err != nil {
+			return "", err
+		}
+		defer os.Remove(f.Name())
+		_, err = f.Write(data)
+		_ = f.Close()
+		if err != nil {
+			return "", err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Dir = config.Get().ProjectRoot()
+		cmd.Env = append(
+			os.Environ(),
+			envQuestionFile+"="+f.Name(),
+			envQuestionSlug+"="+q.TitleSlug,
+			envQuestionID+"="+q.QuestionFrontendId,
+			envLang+"="+lang.Slug(),
+		)
+		cmd.Stdin = strings.NewReader(code)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		err = cmd.Run()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("modifier command %q timed out after %s", name, timeout)
+		}
+		if err != nil {
+			return "", fmt.Errorf("modifier command %q failed: %w\n%s", name, err, stderr.String())
+		}
+		if stdout.Len() == 0 && code != "" {
+			return "", fmt.Errorf("modifier command %q produced no output", name)
+		}
+		return stdout.String(), nil
+	}
+}
+
+// getCommandModifier creates a modifier from the command and timeout fields of a modifier config.
+func getCommandModifier(m map[string]any, lang Lang) (ModifierFunc, error) {
+	args := commandArgs(m["command"])
+	if len(args) == 0 {
+		return nil, errors.New("command is empty")
+	}
+	// Relative paths like ./scripts/fmt.sh are resolved against the project root when running.
+	if !strings.ContainsAny(args[0], `/\`) {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			return nil, fmt.Errorf("command %q not found: %w", args[0], err)
+		}
+	}
+	timeout := defaultCommandTimeout
+	if s := stringField(m, "timeout"); s != "" {
+		var err error
+		timeout, err = time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %q: %w", s, err)
+		}
+	}
+	return newCommandModifier(args, timeout, lang), nil
+}
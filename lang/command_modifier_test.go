@@ -0,0 +1,43 @@
+package lang
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j178/leetgo/leetcode"
+)
+
+func TestCommandModifier(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available")
+	}
+	q := &leetcode.QuestionData{TitleSlug: "two-sum", QuestionFrontendId: "1"}
+
+	modify := newCommandModifier(
+		[]string{"sh", "-c", `sed s/this/t/; grep -o '"slug":"[^"]*"' "$LEETGO_QUESTION_FILE"; echo "$LEETGO_QUESTION_ID $LEETGO_QUESTION_SLUG $LEETGO_LANG"`},
+		time.Second,
+		golangGen,
+	)
+	got, err := modify("func (this *Foo) Bar() {}\n", q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "func (t *Foo) Bar() {}\n\"slug\":\"two-sum\"\n1 two-sum golang\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	modify = newCommandModifier([]string{"sh", "-c", "echo broken >&2; exit 1"}, time.Second, golangGen)
+	_, err = modify("code", q)
+	if err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("expected error with stderr, got %v", err)
+	}
+
+	modify = newCommandModifier([]string{"sleep", "5"}, 100*time.Millisecond, golangGen)
+	_, err = modify("code", q)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+}
@@ -0,0 +1,390 @@
+package lang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/leetcode"
+	"github.com/j178/leetgo/utils"
+)
+
+var (
+	complexitySizes   = []int{100, 300, 1000, 3000, 10000, 30000, 100000}
+	complexityRepeat  = 3
+	complexityTimeout = 10 * time.Second
+)
+
+type complexityModel struct {
+	Name string
+	f    func(n float64) float64
+}
+
+var complexityModels = []complexityModel{
+	{"O(1)", func(n float64) float64 { return 1 }},
+	{"O(log n)", func(n float64) float64 { return math.Log2(n) }},
+	{"O(n)", func(n float64) float64 { return n }},
+	{"O(n log n)", func(n float64) float64 { return n * math.Log2(n) }},
+	{"O(n²)", func(n float64) float64 { return n * n }},
+	{"O(n² log n)", func(n float64) float64 { return n * n * math.Log2(n) }},
+	{"O(n³)", func(n float64) float64 { return n * n * n }},
+}
+
+type ComplexitySample struct {
+	Size    int
+	Elapsed time.Duration
+	Err     error
+}
+
+type ComplexityFit struct {
+	Model    string
+	coef     float64
+	f        func(n float64) float64
+	Residual float64
+}
+
+// MaxProjection is the longest projected runtime, longer projections are clamped to it.
+const MaxProjection = time.Duration(math.MaxInt64)
+
+// Project estimates the runtime of input size n.
+func (f ComplexityFit) Project(n int) time.Duration {
+	ns := f.coef * f.f(float64(n))
+	if ns >= float64(MaxProjection) {
+		return MaxProjection
+	}
+	return time.Duration(ns)
+}
+
+type ComplexityReport struct {
+	Samples []ComplexitySample
+	// Fits are sorted by residual, the first one is the best fit.
+	Fits    []ComplexityFit
+	MaxSize int
+}
+
+func (r *ComplexityReport) Best() ComplexityFit {
+	return r.Fits[0]
+}
+
+// fitComplexity fits samples against every model with least squares on relative error.
+// Timings are measured around the solution call only, so no intercept is needed.
+func fitComplexity(samples []ComplexitySample) []ComplexityFit {
+	fits := make([]ComplexityFit, 0, len(complexityModels))
+	for _, m := range complexityModels {
+		var num, den float64
+		for _, s := range samples {
+			t := math.Max(float64(s.Elapsed), 1)
+			x := m.f(float64(s.Size))
+			num += x / t
+			den += x * x / (t * t)
+		}
+		coef := num / den
+		var residual float64
+		for _, s := range samples {
+			t := math.Max(float64(s.Elapsed), 1)
+			e := 1 - coef*m.f(float64(s.Size))/t
+			residual += e * e
+		}
+		fits = append(fits, ComplexityFit{Model: m.Name, coef: coef, f: m.f, Residual: residual})
+	}
+	sort.SliceStable(
+		fits, func(i, j int) bool {
+			return fits[i].Residual < fits[j].Residual
+		},
+	)
+	return fits
+}
+
+func randomLetters(r *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('a' + r.Intn(26))
+	}
+	return string(b)
+}
+
+func isScalarType(ty string) bool {
+	switch ty {
+	case "integer", "long", "double", "boolean", "character":
+		return true
+	}
+	return false
+}
+
+// generateValue generates a random value of LeetCode type ty that has about n elements.
+// Integer scalars are in [1, n], generateInput picks them from the constraints instead.
+func generateValue(r *rand.Rand, ty string, n int) (string, error) {
+	switch ty {
+	case "integer", "long":
+		return strconv.Itoa(1 + r.Intn(n)), nil
+	case "double":
+		return strconv.FormatFloat(r.Float64()*float64(n), 'f', 5, 64), nil
+	case "boolean":
+		return strconv.FormatBool(r.Intn(2) == 0), nil
+	case "character":
+		return strconv.Quote(randomLetters(r, 1)), nil
+	case "string":
+		return strconv.Quote(randomLetters(r, n)), nil
+	case "TreeNode", "ListNode":
+		return generateValue(r, "integer[]", n)
+	}
+	// list<T> is written like T[] in test cases.
+	if strings.HasPrefix(ty, "list<") && strings.HasSuffix(ty, ">") {
+		return generateValue(r, ty[len("list<"):len(ty)-1]+"[]", n)
+	}
+	if strings.HasSuffix(ty, "[]") {
+		elemType := ty[:len(ty)-2]
+		count, elemSize := n, n
+		// Nested elements share the budget, e.g. a sqrt(n) x sqrt(n) matrix.
+		if !isScalarType(elemType) {
+			count = int(math.Max(1, math.Sqrt(float64(n))))
+			elemSize = count
+		}
+		elems := make([]string, count)
+		for i := range elems {
+			if elemType == "integer" || elemType == "long" {
+				elems[i] = strconv.Itoa(1 + r.Intn(elemSize))
+				continue
+			}
+			elem, err := generateValue(r, elemType, elemSize)
+			if err != nil {
+				return "", err
+			}
+			elems[i] = elem
+		}
+		return "[" + strings.Join(elems, ",") + "]", nil
+	}
+	return "", fmt.Errorf("cannot generate input of type %s", ty)
+}
+
+func generateInput(r *rand.Rand, q *leetcode.QuestionData, n int, bounds map[string]float64) (string, error) {
+	lines := make([]string, 0, len(q.MetaData.Params))
+	for _, p := range q.MetaData.Params {
+		if p.Type == "integer" || p.Type == "long" {
+			lines = append(lines, strconv.Itoa(integerParam(r, p.Name, n, bounds)))
+			continue
+		}
+		v, err := generateValue(r, p.Type, n)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, v)
+	}
+	return utils.EnsureTrailingNewline(strings.Join(lines, "\n")), nil
+}
+
+// integerParam picks the value of an integer parameter: sizes like n, or k in "1 <= k <= nums.length", are set to n;
+// other parameters are random values within their upper bound, e.g. target in "-10^9 <= target <= 10^9".
+func integerParam(r *rand.Rand, name string, n int, bounds map[string]float64) int {
+	bound, ok := bounds[name]
+	switch {
+	case ok && bound == sizeBound:
+		return n
+	case name == "n":
+		if ok && bound < float64(n) {
+			return int(math.Max(bound, 1))
+		}
+		return n
+	case ok && bound >= 1:
+		return 1 + r.Intn(int(math.Min(bound, 1e9)))
+	}
+	return 1 + r.Intn(n)
+}
+
+var (
+	constraintPat = regexp.MustCompile(`(?s)<li>(.*?)</li>`)
+	upperBoundPat = regexp.MustCompile(`^(?:(\d+)\s*\*\s*)?(\d+)(?:\^(\d+))?`)
+	sizeNamePat   = regexp.MustCompile(`\.length|\bn\b`)
+	tagPat        = regexp.MustCompile(`<[^>]+>`)
+	paramNamePat  = regexp.MustCompile(`^[a-zA-Z_]\w*$`)
+)
+
+// sizeBound is the bound of parameters that are limited by an input size, e.g. k in "1 <= k <= nums.length".
+const sizeBound = -1
+
+// constraints returns the constraints of the question as plain text, e.g. "1 <= nums.length <= 10^4".
+func constraints(q *leetcode.QuestionData) []string {
+	content, _ := q.GetContent()
+	var texts []string
+	for _, m := range constraintPat.FindAllStringSubmatch(content, -1) {
+		text := strings.ReplaceAll(m[1], "<sup>", "^")
+		text = tagPat.ReplaceAllString(text, "")
+		text = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&nbsp;", " ").Replace(text)
+		texts = append(texts, text)
+	}
+	return texts
+}
+
+// parseUpperBound parses bounds like "10^5", "2 * 10^4" or "500".
+func parseUpperBound(text string) (float64, bool) {
+	bound := upperBoundPat.FindStringSubmatch(strings.TrimSpace(text))
+	if bound == nil {
+		return 0, false
+	}
+	v, _ := strconv.ParseFloat(bound[2], 64)
+	if bound[3] != "" {
+		exp, _ := strconv.ParseFloat(bound[3], 64)
+		v = math.Pow(v, exp)
+	}
+	if bound[1] != "" {
+		k, _ := strconv.ParseFloat(bound[1], 64)
+		v *= k
+	}
+	return v, true
+}
+
+// integerBounds finds the upper bounds of parameters from constraints like "lo <= name <= hi".
+// Bounds that refer to a size, e.g. "nums.length" or "n", are sizeBound.
+func integerBounds(q *leetcode.QuestionData) map[string]float64 {
+	bounds := make(map[string]float64)
+	for _, text := range constraints(q) {
+		parts := strings.Split(text, "<=")
+		if len(parts) != 3 {
+			continue
+		}
+		name := strings.TrimSpace(parts[1])
+		if !paramNamePat.MatchString(name) {
+			continue
+		}
+		if sizeNamePat.MatchString(parts[2]) {
+			bounds[name] = sizeBound
+		} else if v, ok := parseUpperBound(parts[2]); ok {
+			bounds[name] = v
+		}
+	}
+	return bounds
+}
+
+// guessMaxSize guesses the maximum input size from the constraints of the question.
+func guessMaxSize(q *leetcode.QuestionData) int {
+	maxSize := 0
+	for _, text := range constraints(q) {
+		idx := strings.LastIndex(text, "<=")
+		if idx < 0 || !sizeNamePat.MatchString(text) {
+			continue
+		}
+		v, ok := parseUpperBound(text[idx+2:])
+		if ok && v > float64(maxSize) && v <= 1e9 {
+			maxSize = int(v)
+		}
+	}
+	if maxSize == 0 {
+		return complexitySizes[len(complexitySizes)-1]
+	}
+	return maxSize
+}
+
+func timeSolution(args []string, dir string, env []string, input string, file string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), complexityTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = strings.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return 0, errors.New("time limit exceeded")
+	}
+	if err != nil {
+		_, stdout := extractOutput(string(output))
+		return 0, fmt.Errorf("runtime error: %w\n%s", err, stdout)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return 0, err
+	}
+	ns, err := strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timing: %w", err)
+	}
+	return time.Duration(ns), nil
+}
+
+// EstimateComplexity runs the local solution on random inputs of increasing size,
+// and fits the timings against common complexity classes.
+// If maxSize is 0, it is guessed from the question constraints.
+func EstimateComplexity(q *leetcode.QuestionData, maxSize int) (*ComplexityReport, error) {
+	cfg := config.Get()
+	gen, err := GetGenerator(cfg.Code.Lang)
+	if err != nil {
+		return nil, err
+	}
+	builder, _ := gen.(Buildable)
+	timer, _ := gen.(Timer)
+	if builder == nil || timer == nil {
+		return nil, fmt.Errorf("language %s does not support complexity estimation", gen.Slug())
+	}
+	err = q.Fulfill()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question data: %w", err)
+	}
+	if q.MetaData.SystemDesign {
+		return nil, errors.New("complexity estimation of system design questions is not supported")
+	}
+	outDir := getOutDir(q, gen)
+	if !utils.IsExist(outDir) {
+		return nil, fmt.Errorf("no code generated for %s in language %s", q.TitleSlug, gen.Slug())
+	}
+	if maxSize <= 0 {
+		maxSize = guessMaxSize(q)
+	}
+
+	buildDir, err := os.MkdirTemp("", "leetgo-complexity")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(buildDir) }()
+	args, err := builder.Build(q, outDir, buildDir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := rand.New(rand.NewSource(1))
+	timingFile := filepath.Join(buildDir, "timing")
+	env := timer.TimingEnv(timingFile)
+	bounds := integerBounds(q)
+	report := &ComplexityReport{MaxSize: maxSize}
+	var succeeded []ComplexitySample
+	for _, n := range complexitySizes {
+		input, err := generateInput(r, q, n, bounds)
+		if err != nil {
+			return nil, err
+		}
+		sample := ComplexitySample{Size: n}
+		for i := 0; i < complexityRepeat; i++ {
+			elapsed, err := timeSolution(args, outDir, env, input, timingFile)
+			if err != nil {
+				sample.Err = err
+				break
+			}
+			if sample.Elapsed == 0 || elapsed < sample.Elapsed {
+				sample.Elapsed = elapsed
+			}
+		}
+		log.Debug("complexity sample", "size", n, "elapsed", sample.Elapsed, "err", sample.Err)
+		report.Samples = append(report.Samples, sample)
+		if sample.Err != nil {
+			// Larger inputs would fail too.
+			break
+		}
+		succeeded = append(succeeded, sample)
+	}
+	if len(succeeded) < 3 {
+		return report, errors.New("not enough successful runs to estimate complexity")
+	}
+	report.Fits = fitComplexity(succeeded)
+	return report, nil
+}
@@ -0,0 +1,96 @@
+package lang
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFitComplexity(t *testing.T) {
+	testCases := []struct {
+		name     string
+		f        func(n float64) float64
+		expected string
+	}{
+		{
+			name:     "Constant",
+			f:        func(n float64) float64 { return 500 },
+			expected: "O(1)",
+		},
+		{
+			name:     "Linear",
+			f:        func(n float64) float64 { return 3 * n },
+			expected: "O(n)",
+		},
+		{
+			name:     "Linearithmic",
+			f:        func(n float64) float64 { return 7 * n * math.Log2(n) },
+			expected: "O(n log n)",
+		},
+		{
+			name:     "Quadratic",
+			f:        func(n float64) float64 { return 0.5 * n * n },
+			expected: "O(n²)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				var samples []ComplexitySample
+				for i, n := range complexitySizes {
+					// Add some noise to the timings.
+					noise := 1 + 0.05*float64(i%2*2-1)
+					samples = append(
+						samples,
+						ComplexitySample{Size: n, Elapsed: time.Duration(tc.f(float64(n)) * noise)},
+					)
+				}
+				fits := fitComplexity(samples)
+				if fits[0].Model != tc.expected {
+					t.Errorf("expected %s, got %s", tc.expected, fits[0].Model)
+				}
+			},
+		)
+	}
+}
+
+func TestProjectClamped(t *testing.T) {
+	fit := ComplexityFit{coef: 1, f: func(n float64) float64 { return n * n * n }}
+	if got := fit.Project(1e9); got != MaxProjection {
+		t.Errorf("expected the projection to be clamped, got %v", got)
+	}
+}
+
+func TestGenerateInput(t *testing.T) {
+	q := questionWithMeta(
+		t,
+		`{"name": "f", "params": [{"name": "nums", "type": "list<list<integer>>"}, {"name": "k", "type": "integer"}, {"name": "target", "type": "integer"}], "return": {"type": "integer"}}`,
+	)
+	q.Content = `<ul>
+<li><code>1 &lt;= nums.length &lt;= 10<sup>4</sup></code></li>
+<li><code>1 &lt;= k &lt;= nums.length</code></li>
+<li><code>1 &lt;= target &lt;= 50</code></li>
+</ul>`
+	bounds := integerBounds(q)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		input, err := generateInput(r, q, 1000, bounds)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(input), "\n")
+		if len(lines) != 3 || !strings.HasPrefix(lines[0], "[[") {
+			t.Fatalf("unexpected input %q", input)
+		}
+		if lines[1] != "1000" {
+			t.Errorf("k is a size, got %s", lines[1])
+		}
+		if target, _ := strconv.Atoi(lines[2]); target < 1 || target > 50 {
+			t.Errorf("target out of its bound: %s", lines[2])
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package lang
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+type cpp struct {
+	baseLang
+}
+
+const cppPreludeFile = "leetcode.h"
+
+// cppPrelude mimics the environment of LeetCode, which includes the standard headers
+// and provides the common data structures.
+const cppPrelude = `// Code generated by leetgo. DO NOT EDIT.
+// This header is force included into every solution, just like LeetCode does.
+#pragma once
+
+#include <algorithm>
+#include <array>
+#include <bitset>
+#include <climits>
+#include <cmath>
+#include <cstring>
+#include <deque>
+#include <functional>
+#include <iostream>
+#include <list>
+#include <map>
+#include <memory>
+#include <numeric>
+#include <queue>
+#include <set>
+#include <sstream>
+#include <stack>
+#include <string>
+#include <tuple>
+#include <unordered_map>
+#include <unordered_set>
+#include <utility>
+#include <vector>
+
+using namespace std;
+
+struct ListNode {
+    int val;
+    ListNode *next;
+    ListNode() : val(0), next(nullptr) {}
+    ListNode(int x) : val(x), next(nullptr) {}
+    ListNode(int x, ListNode *next) : val(x), next(next) {}
+};
+
+struct TreeNode {
+    int val;
+    TreeNode *left;
+    TreeNode *right;
+    TreeNode() : val(0), left(nullptr), right(nullptr) {}
+    TreeNode(int x) : val(x), left(nullptr), right(nullptr) {}
+    TreeNode(int x, TreeNode *left, TreeNode *right) : val(x), left(left), right(right) {}
+};
+`
+
+const cppCMakeLists = `cmake_minimum_required(VERSION 3.16)
+project(leetcode_solutions CXX)
+
+set(CMAKE_CXX_STANDARD 17)
+set(CMAKE_CXX_STANDARD_REQUIRED ON)
+set(CMAKE_EXPORT_COMPILE_COMMANDS ON)
+
+# Every solution is compiled on its own, with the LeetCode prelude force included.
+file(GLOB SOLUTIONS CONFIGURE_DEPENDS ${CMAKE_CURRENT_SOURCE_DIR}/*.cpp)
+foreach(file ${SOLUTIONS})
+    # NAME_WLE keeps every dot but the last, so variants like 0001.two-sum.dp.cpp get their own target.
+    get_filename_component(name ${file} NAME_WLE)
+    string(MAKE_C_IDENTIFIER "q_${name}" target)
+    add_library(${target} OBJECT ${file})
+    target_compile_options(${target} PRIVATE -include ${CMAKE_CURRENT_SOURCE_DIR}/` + cppPreludeFile + `)
+endforeach()
+`
+
+func (c cpp) scaffoldFiles() []scaffoldFile {
+	return []scaffoldFile{
+		{Path: cppPreludeFile, Content: cppPrelude, Managed: true},
+		{Path: "CMakeLists.txt", Content: cppCMakeLists},
+	}
+}
+
+func (c cpp) HasInitialized(outDir string) (bool, error) {
+	files := append(c.scaffoldFiles(), scaffoldFile{Path: "compile_commands.json"})
+	return hasScaffold(outDir, files)
+}
+
+func (c cpp) Initialize(outDir string) error {
+	err := writeScaffold(outDir, c.scaffoldFiles())
+	if err != nil {
+		return err
+	}
+	return c.syncWorkspace(outDir)
+}
+
+type compileCommand struct {
+	Directory string   `json:"directory"`
+	File      string   `json:"file"`
+	Arguments []string `json:"arguments"`
+}
+
+// syncWorkspace writes compile_commands.json for clangd, so it works without running cmake first.
+func (c cpp) syncWorkspace(outDir string) error {
+	dir, err := filepath.Abs(outDir)
+	if err != nil {
+		return err
+	}
+	files, err := listSourceFiles(dir, c.extension)
+	if err != nil {
+		return err
+	}
+	commands := make([]compileCommand, 0, len(files))
+	for _, f := range files {
+		commands = append(
+			commands, compileCommand{
+				Directory: dir,
+				File:      filepath.Join(dir, f),
+				Arguments: []string{
+					"c++",
+					"-std=c++17",
+					"-include",
+					filepath.Join(dir, cppPreludeFile),
+					"-c",
+					filepath.Join(dir, f),
+				},
+			},
+		)
+	}
+	content, err := json.MarshalIndent(commands, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "compile_commands.json"), content, 0o644)
+}
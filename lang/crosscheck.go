@@ -0,0 +1,62 @@
+package lang
+
+import (
+	"strings"
+
+	"github.com/j178/leetgo/leetcode"
+)
+
+// CrossCheckRow lines up the local and remote answers of a single test case.
+type CrossCheckRow struct {
+	No       int
+	Input    string
+	Local    string
+	Remote   string
+	Expected string
+	Mismatch bool
+}
+
+// CrossCheckInputs returns the input of every case that was run locally.
+func CrossCheckInputs(results []CaseResult) []string {
+	var inputs []string
+	for _, r := range results {
+		if r.Status == CaseSkipped {
+			continue
+		}
+		inputs = append(inputs, strings.Join(r.Input, "\n"))
+	}
+	return inputs
+}
+
+// CrossCheck lines up local results with the remote result case by case.
+// The remote result must come from running CrossCheckInputs(local).
+func CrossCheck(q *leetcode.QuestionData, local []CaseResult, remote *leetcode.RunCheckResult) []CrossCheckRow {
+	var rows []CrossCheckRow
+	i := 0
+	for _, r := range local {
+		if r.Status == CaseSkipped {
+			continue
+		}
+		row := CrossCheckRow{
+			No:    r.No,
+			Input: strings.Join(r.Input, "\n"),
+			Local: r.Output,
+		}
+		if r.Output == "" {
+			row.Local = string(r.Status)
+		}
+		notRun := i < len(remote.CompareResult) && remote.CompareResult[i] == leetcode.CaseNotRun
+		if i < len(remote.CodeAnswer) && !notRun {
+			row.Remote = remote.CodeAnswer[i]
+		} else {
+			row.Remote = remote.StatusMsg
+		}
+		if i < len(remote.ExpectedCodeAnswer) {
+			row.Expected = remote.ExpectedCodeAnswer[i]
+		}
+		row.Mismatch = !judgeResult(q, row.Local, row.Remote) || !judgeResult(q, row.Remote, row.Expected)
+		rows = append(rows, row)
+		i++
+	}
+	return rows
+}
@@ -0,0 +1,243 @@
+package lang
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"text/template"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/leetcode"
+	"github.com/j178/leetgo/utils"
+)
+
+// customLang is a language declared in config, it supports local test by running configured commands.
+type customLang struct {
+	baseLang
+	build           []string
+	run             []string
+	headerTemplate  string
+	harnessTemplate string
+}
+
+func newCustomLang(c config.CustomLang) Lang {
+	base := baseLang{
+		name:              c.Name,
+		slug:              c.Slug,
+		shortName:         c.ShortName,
+		extension:         c.Extension,
+		lineComment:       c.LineComment,
+		blockCommentStart: c.BlockCommentStart,
+		blockCommentEnd:   c.BlockCommentEnd,
+	}
+	if base.shortName == "" {
+		base.shortName = base.slug
+	}
+	// Without a run command, it's just a language that can be generated.
+	if len(c.Run) == 0 {
+		return base
+	}
+	return customLang{
+		baseLang:        base,
+		build:           c.Build,
+		run:             c.Run,
+		headerTemplate:  c.HeaderTemplate,
+		harnessTemplate: c.HarnessTemplate,
+	}
+}
+
+func customLangs() []Lang {
+	var langs []Lang
+	for _, c := range config.Get().Code.CustomLangs {
+		langs = append(langs, newCustomLang(c))
+	}
+	return langs
+}
+
+// allLangs returns custom languages before the builtin ones, so they can override the builtin languages.
+func allLangs() []Lang {
+	return append(customLangs(), SupportedLangs...)
+}
+
+type harnessData struct {
+	Question   *leetcode.QuestionData
+	MetaData   *leetcode.MetaData
+	OutputMark string
+}
+
+func (l customLang) renderHarness(q *leetcode.QuestionData, text string) (string, error) {
+	tmpl := template.New("harness")
+	tmpl.Funcs(utils.TemplateFuncs())
+	tmpl.Funcs(template.FuncMap{"title": toGoFuncName})
+	_, err := tmpl.Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid harness template of %s: %w", l.name, err)
+	}
+	var buf bytes.Buffer
+	err = tmpl.Execute(
+		&buf, &harnessData{
+			Question:   q,
+			MetaData:   &q.MetaData,
+			OutputMark: testCaseOutputMark,
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("render harness template of %s failed: %w", l.name, err)
+	}
+	return buf.String(), nil
+}
+
+func (l customLang) GeneratePaths(q *leetcode.QuestionData) (*GenerateResult, error) {
+	filenameTmpl := getFilenameTemplate(q, l)
+	baseFilename, err := q.GetFormattedFilename(l.slug, filenameTmpl)
+	if err != nil {
+		return nil, err
+	}
+	genResult := &GenerateResult{
+		SubDir:   baseFilename,
+		Question: q,
+		Lang:     l,
+	}
+	genResult.AddFile(
+		FileOutput{
+			Filename: "solution" + l.extension,
+			Type:     CodeFile | TestFile,
+		},
+	)
+	genResult.AddFile(
+		FileOutput{
+			Filename: "testcases.txt",
+			Type:     TestCasesFile,
+		},
+	)
+	if separateDescriptionFile(l) {
+		genResult.AddFile(
+			FileOutput{
+				Filename: "question.md",
+				Type:     DocFile,
+			},
+		)
+	}
+	return genResult, nil
+}
+
+func (l customLang) Generate(q *leetcode.QuestionData) (*GenerateResult, error) {
+	filenameTmpl := getFilenameTemplate(q, l)
+	baseFilename, err := q.GetFormattedFilename(l.slug, filenameTmpl)
+	if err != nil {
+		return nil, err
+	}
+	genResult := &GenerateResult{
+		SubDir:   baseFilename,
+		Question: q,
+		Lang:     l,
+	}
+
+	separateDescriptionFile := separateDescriptionFile(l)
+	blocks, err := getBlocks(l)
+	if err != nil {
+		return nil, err
+	}
+	modifiers, err := getModifiers(l, builtinModifiers)
+	if err != nil {
+		return nil, err
+	}
+	header, err := l.renderHarness(q, l.headerTemplate)
+	if err != nil {
+		return nil, err
+	}
+	harness, err := l.renderHarness(q, l.harnessTemplate)
+	if err != nil {
+		return nil, err
+	}
+	blocks = append(
+		blocks,
+		config.Block{
+			Name:     internalBeforeMarker,
+			Template: header,
+		},
+		config.Block{
+			Name:     internalAfterMarker,
+			Template: harness,
+		},
+	)
+	content, err := l.generateCodeContent(q, blocks, modifiers, separateDescriptionFile)
+	if err != nil {
+		return nil, err
+	}
+	genResult.AddFile(
+		FileOutput{
+			Filename: "solution" + l.extension,
+			Content:  content,
+			Type:     CodeFile | TestFile,
+		},
+	)
+	testcaseFile, err := l.generateTestCasesFile(q, "testcases.txt")
+	if err != nil {
+		return nil, err
+	}
+	genResult.AddFile(testcaseFile)
+
+	if separateDescriptionFile {
+		docFile, err := l.generateDescriptionFile(q, baseFilename, "question.md")
+		if err != nil {
+			return nil, err
+		}
+		genResult.AddFile(docFile)
+	}
+	return genResult, nil
+}
+
+type commandData struct {
+	Dir  string
+	File string
+}
+
+func renderCommand(args []string, data *commandData) ([]string, error) {
+	rendered := make([]string, 0, len(args))
+	for _, arg := range args {
+		tmpl, err := template.New("command").Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid command %q: %w", arg, err)
+		}
+		var buf bytes.Buffer
+		err = tmpl.Execute(&buf, data)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, buf.String())
+	}
+	return rendered, nil
+}
+
+func (l customLang) RunLocalTest(q *leetcode.QuestionData, outDir string, opts ...TestOption) (bool, error) {
+	genResult, err := l.GeneratePaths(q)
+	if err != nil {
+		return false, fmt.Errorf("generate paths failed: %w", err)
+	}
+	applyVariant(genResult, newTestOptions(opts).variant)
+	genResult.SetOutDir(outDir)
+	data := &commandData{
+		Dir:  genResult.CodeDir(),
+		File: genResult.GetFile(CodeFile).GetPath(),
+	}
+
+	if len(l.build) > 0 {
+		args, err := renderCommand(l.build, data)
+		if err != nil {
+			return false, err
+		}
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Dir = outDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return false, fmt.Errorf("build failed: %w\n%s", err, output)
+		}
+	}
+
+	args, err := renderCommand(l.run, data)
+	if err != nil {
+		return false, err
+	}
+	return runTest(q, genResult, args, outDir, opts...)
+}
@@ -0,0 +1,228 @@
+package lang
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-wordwrap"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/leetcode"
+	"github.com/j178/leetgo/utils"
+)
+
+// Extra sections of the question description, enabled by code.description_sections.
+const (
+	sectionTags             = "tags"
+	sectionHints            = "hints"
+	sectionSimilarQuestions = "similar_questions"
+	sectionStats            = "stats"
+)
+
+var descriptionSectionNames = []string{sectionTags, sectionHints, sectionSimilarQuestions, sectionStats}
+
+func getDescriptionSections() ([]string, error) {
+	sections := config.Get().Code.DescriptionSections
+	valid := make(map[string]bool, len(descriptionSectionNames))
+	for _, name := range descriptionSectionNames {
+		valid[name] = true
+	}
+	for _, name := range sections {
+		if !valid[name] {
+			return nil, fmt.Errorf(
+				"unknown description section %q, valid sections: %s",
+				name,
+				strings.Join(descriptionSectionNames, ", "),
+			)
+		}
+	}
+	return sections, nil
+}
+
+type sectionLabels struct {
+	Tags             string
+	Hints            string
+	Hint             string
+	SimilarQuestions string
+	Stats            string
+	Accepted         string
+	Submissions      string
+	AcRate           string
+}
+
+var (
+	enSectionLabels = sectionLabels{
+		Tags:             "Tags",
+		Hints:            "Hints",
+		Hint:             "Hint",
+		SimilarQuestions: "Similar Questions",
+		Stats:            "Stats",
+		Accepted:         "Accepted",
+		Submissions:      "Submissions",
+		AcRate:           "Acceptance Rate",
+	}
+	zhSectionLabels = sectionLabels{
+		Tags:             "标签",
+		Hints:            "提示",
+		Hint:             "提示",
+		SimilarQuestions: "相似题目",
+		Stats:            "统计",
+		Accepted:         "通过次数",
+		Submissions:      "提交次数",
+		AcRate:           "通过率",
+	}
+)
+
+func getSectionLabels() sectionLabels {
+	if config.Get().Language == config.ZH {
+		return zhSectionLabels
+	}
+	return enSectionLabels
+}
+
+// similarQuestion is a similar question and its local solution file.
+type similarQuestion struct {
+	Question *leetcode.QuestionData
+	// File is the path of the solution file, empty if the question is not generated yet.
+	File string
+}
+
+func (s similarQuestion) title() string {
+	if s.Question.QuestionFrontendId == "" {
+		return s.Question.GetTitle()
+	}
+	return s.Question.QuestionFrontendId + ". " + s.Question.GetTitle()
+}
+
+func getSimilarQuestions(q *leetcode.QuestionData, slug string) []similarQuestion {
+	gen, _ := GetGenerator(slug)
+	var questions []similarQuestion
+	for _, sq := range q.GetSimilarQuestions() {
+		s := similarQuestion{Question: sq}
+		if gen != nil && sq.QuestionFrontendId != "" {
+			paths, err := gen.GeneratePaths(sq)
+			if err == nil {
+				paths.SetOutDir(getOutDir(sq, gen))
+				if f := paths.GetFile(CodeFile); f != nil && utils.IsExist(f.GetPath()) {
+					s.File = f.GetPath()
+				}
+			}
+		}
+		questions = append(questions, s)
+	}
+	return questions
+}
+
+// markdownSections renders the sections for the description file in dir.
+// Hints are collapsed, so that they are not read by accident.
+func markdownSections(q *leetcode.QuestionData, slug string, names []string, dir string) string {
+	labels := getSectionLabels()
+	var sb strings.Builder
+	for _, name := range names {
+		switch name {
+		case sectionTags:
+			tags := q.TagNames()
+			if len(tags) == 0 {
+				continue
+			}
+			fmt.Fprintf(&sb, "\n## %s\n\n", labels.Tags)
+			for i, tag := range tags {
+				if i > 0 {
+					sb.WriteString(" ")
+				}
+				sb.WriteString("`" + tag + "`")
+			}
+			sb.WriteString("\n")
+		case sectionHints:
+			hints := q.GetHints()
+			if len(hints) == 0 {
+				continue
+			}
+			fmt.Fprintf(&sb, "\n## %s\n\n", labels.Hints)
+			for i, hint := range hints {
+				fmt.Fprintf(&sb, "<details>\n<summary>%s %d</summary>\n\n%s\n\n</details>\n\n", labels.Hint, i+1, hint)
+			}
+		case sectionSimilarQuestions:
+			questions := getSimilarQuestions(q, slug)
+			if len(questions) == 0 {
+				continue
+			}
+			fmt.Fprintf(&sb, "\n## %s\n\n", labels.SimilarQuestions)
+			for _, s := range questions {
+				link := s.Question.Url()
+				if s.File != "" {
+					if rel, err := filepath.Rel(dir, s.File); err == nil {
+						link = filepath.ToSlash(rel)
+					}
+				}
+				fmt.Fprintf(&sb, "- [%s](%s) (%s)\n", s.title(), link, s.Question.Difficulty)
+			}
+		case sectionStats:
+			if q.Stats.TotalAccepted == "" {
+				continue
+			}
+			fmt.Fprintf(&sb, "\n## %s\n\n", labels.Stats)
+			fmt.Fprintf(&sb, "- %s: %s\n", labels.Accepted, q.Stats.TotalAccepted)
+			fmt.Fprintf(&sb, "- %s: %s\n", labels.Submissions, q.Stats.TotalSubmission)
+			fmt.Fprintf(&sb, "- %s: %s\n", labels.AcRate, q.Stats.ACRate)
+		}
+	}
+	return sb.String()
+}
+
+// descriptionSections are the sections rendered as plain text for the description in code comments.
+// Each of them starts with a blank line, or is empty if the section is not enabled.
+type descriptionSections struct {
+	Tags             string
+	Hints            string
+	SimilarQuestions string
+	Stats            string
+}
+
+func commentSections(q *leetcode.QuestionData, slug string, names []string) descriptionSections {
+	labels := getSectionLabels()
+	var sections descriptionSections
+	for _, name := range names {
+		switch name {
+		case sectionTags:
+			if tags := q.TagNames(); len(tags) > 0 {
+				sections.Tags = fmt.Sprintf("\n\n%s: %s", labels.Tags, strings.Join(tags, ", "))
+			}
+		case sectionHints:
+			var sb strings.Builder
+			for i, hint := range q.GetHints() {
+				fmt.Fprintf(&sb, "\n\n%s %d: %s", labels.Hint, i+1, hint)
+			}
+			sections.Hints = wordwrap.WrapString(sb.String(), 100)
+		case sectionSimilarQuestions:
+			questions := getSimilarQuestions(q, slug)
+			if len(questions) == 0 {
+				continue
+			}
+			var sb strings.Builder
+			fmt.Fprintf(&sb, "\n\n%s:", labels.SimilarQuestions)
+			for _, s := range questions {
+				location := s.Question.Url()
+				if s.File != "" {
+					// Paths in comments are relative to the project root, which is where editors usually open.
+					if rel, err := filepath.Rel(config.Get().ProjectRoot(), s.File); err == nil {
+						location = filepath.ToSlash(rel)
+					}
+				}
+				fmt.Fprintf(&sb, "\n- %s (%s) %s", s.title(), s.Question.Difficulty, location)
+			}
+			sections.SimilarQuestions = sb.String()
+		case sectionStats:
+			if q.Stats.TotalAccepted != "" {
+				sections.Stats = fmt.Sprintf(
+					"\n\n%s: %s, %s: %s, %s: %s",
+					labels.Accepted, q.Stats.TotalAccepted,
+					labels.Submissions, q.Stats.TotalSubmission,
+					labels.AcRate, q.Stats.ACRate,
+				)
+			}
+		}
+	}
+	return sections
+}
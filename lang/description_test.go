@@ -0,0 +1,42 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/j178/leetgo/leetcode"
+)
+
+func TestDescriptionSections(t *testing.T) {
+	q := &leetcode.QuestionData{
+		TopicTags: []leetcode.TopicTag{
+			{Name: "Array", TranslatedName: "数组"},
+			{Name: "Hash Table", TranslatedName: "哈希表"},
+		},
+		Hints: []string{"Try a <b>hash map</b>.", "One pass is enough."},
+		Stats: leetcode.Stats{TotalAccepted: "3.9M", TotalSubmission: "7.6M", ACRate: "51.2%"},
+	}
+	names := []string{sectionStats, sectionTags, sectionHints}
+
+	got := markdownSections(q, "golang", names, ".")
+	want := "\n## 统计\n\n- 通过次数: 3.9M\n- 提交次数: 7.6M\n- 通过率: 51.2%\n" +
+		"\n## 标签\n\n`数组` `哈希表`\n" +
+		"\n## 提示\n\n<details>\n<summary>提示 1</summary>\n\nTry a hash map.\n\n</details>\n\n" +
+		"<details>\n<summary>提示 2</summary>\n\nOne pass is enough.\n\n</details>\n\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	sections := commentSections(q, "golang", names)
+	if sections.Tags != "\n\n标签: 数组, 哈希表" {
+		t.Errorf("unexpected tags: %q", sections.Tags)
+	}
+	if sections.Hints != "\n\n提示 1: Try a hash map.\n\n提示 2: One pass is enough." {
+		t.Errorf("unexpected hints: %q", sections.Hints)
+	}
+	if sections.Stats != "\n\n通过次数: 3.9M, 提交次数: 7.6M, 通过率: 51.2%" {
+		t.Errorf("unexpected stats: %q", sections.Stats)
+	}
+	if sections.SimilarQuestions != "" {
+		t.Errorf("similar questions are not enabled, got %q", sections.SimilarQuestions)
+	}
+}
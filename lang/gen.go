@@ -7,6 +7,8 @@ import (
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/charmbracelet/log"
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
 	"github.com/spf13/viper"
 
 	"github.com/j178/leetgo/config"
@@ -16,17 +18,18 @@ import (
 
 func GetGenerator(lang string) (Lang, error) {
 	lang = strings.ToLower(lang)
-	for _, l := range SupportedLangs {
+	langs := allLangs()
+	for _, l := range langs {
 		if l.Slug() == lang {
 			return l, nil
 		}
 	}
-	for _, l := range SupportedLangs {
+	for _, l := range langs {
 		if l.ShortName() == lang {
 			return l, nil
 		}
 	}
-	for _, l := range SupportedLangs {
+	for _, l := range langs {
 		if strings.HasPrefix(strings.ToLower(l.Name()), lang) {
 			return l, nil
 		}
@@ -40,38 +43,34 @@ func generate(q *leetcode.QuestionData) (Lang, *GenerateResult, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	variant := currentVariant()
+	err = checkVariant(variant)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	err = q.Fulfill()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get question data: %w", err)
 	}
 
-	codeSnippet := q.GetCodeSnippet(gen.Slug())
-	if codeSnippet == "" {
-		return nil, nil, fmt.Errorf(`question "%s" doesn't support using "%s"`, cfg.Code.Lang, q.TitleSlug)
-	}
-
-	outDir := getOutDir(q, gen)
-	err = utils.CreateIfNotExists(outDir, true)
+	err = ensureCodeSnippet(gen, q)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Check and generate necessary library files.
-	if t, ok := gen.(NeedInitialization); ok {
-		ok, err := t.HasInitialized(outDir)
-		if err == nil && !ok {
-			err = t.Initialize(outDir)
-			if err != nil {
-				return nil, nil, err
-			}
+	outDir := getOutDir(q, gen)
+	dryRun := IsDryRun()
+	if !dryRun {
+		err = utils.CreateIfNotExists(outDir, true)
+		if err != nil {
+			return nil, nil, err
 		}
+
+		// Check and generate necessary library files.
+		err = initializeWorkspace(gen, outDir, false)
 		if err != nil {
-			log.Error(
-				"check initialization failed, skip initialization",
-				"lang", gen.Slug(),
-				"err", err,
-			)
+			return nil, nil, err
 		}
 	}
 
@@ -80,15 +79,35 @@ func generate(q *leetcode.QuestionData) (Lang, *GenerateResult, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	applyVariant(result, variant)
 	result.SetOutDir(outDir)
+	images := localizeImages(result)
 	// Write files
 	for i, file := range result.Files {
+		// Test cases and description are shared by all variants, keep them as they are.
+		if variant != "" && file.Type&(CodeFile|TestFile) == 0 && utils.IsExist(file.GetPath()) {
+			continue
+		}
 		written, err := tryWrite(file.GetPath(), file.Content)
 		if err != nil {
 			log.Error("failed to write file", "path", file.GetPath(), "err", err)
 			continue
 		}
 		result.Files[i].Written = written
+		if written {
+			downloadImages(images[file.GetPath()])
+		}
+	}
+	if dryRun {
+		return gen, result, nil
+	}
+	err = writeQuestionMeta(result)
+	if err != nil {
+		log.Error("failed to write question metadata", "question", q.TitleSlug, "err", err)
+	}
+	err = syncWorkspace(gen, outDir)
+	if err != nil {
+		log.Error("failed to update workspace files", "lang", gen.Slug(), "err", err)
 	}
 	return gen, result, nil
 }
@@ -98,13 +117,18 @@ func Generate(q *leetcode.QuestionData) (*GenerateResult, error) {
 	if err != nil {
 		return nil, err
 	}
+	if IsDryRun() {
+		return result, nil
+	}
 
 	state := config.LoadState()
-	state.LastQuestion = config.LastQuestion{
-		Slug:       q.TitleSlug,
-		FrontendID: q.QuestionFrontendId,
-		Gen:        gen.Slug(),
-	}
+	state.SetLastQuestion(
+		config.LastQuestion{
+			Slug:       q.TitleSlug,
+			FrontendID: q.QuestionFrontendId,
+			Gen:        gen.Slug(),
+		},
+	)
 	config.SaveState(state)
 
 	return result, nil
@@ -128,6 +152,9 @@ func GenerateContest(ct *leetcode.Contest) ([]*GenerateResult, error) {
 	if len(results) == 0 {
 		return nil, fmt.Errorf("no question generated")
 	}
+	if IsDryRun() {
+		return results, nil
+	}
 
 	state := config.LoadState()
 	state.LastContest = ct.TitleSlug
@@ -136,22 +163,104 @@ func GenerateContest(ct *leetcode.Contest) ([]*GenerateResult, error) {
 	return results, nil
 }
 
+// IsDryRun reports whether --dry-run is set, files are printed instead of written in dry-run mode.
+func IsDryRun() bool {
+	return viper.GetBool("dry-run")
+}
+
+// printDryRun prints what would be written to file: the content of a new file, or the diff of an existing one.
+func printDryRun(file string, content string) {
+	relPath := utils.RelToCwd(file)
+	old, err := os.ReadFile(file)
+	switch {
+	case err != nil:
+		fmt.Printf("%s %s\n%s\n", "create", stdoutStyle.Render(relPath), content)
+	case string(old) == content:
+		fmt.Printf("%s %s\n\n", "unchanged", stdoutStyle.Render(relPath))
+	default:
+		fmt.Printf("%s %s\n%s\n", "overwrite", stdoutStyle.Render(relPath), unifiedDiff(relPath, string(old), content))
+	}
+}
+
+func unifiedDiff(path string, old string, new string) string {
+	edits := myers.ComputeEdits("", old, new)
+	return fmt.Sprint(gotextdiff.ToUnified(path, path, old, edits))
+}
+
+// Choices for a file that already exists.
+const (
+	overwriteFile     = "Overwrite"
+	showDiff          = "Show diff"
+	skipFile          = "Skip"
+	writeNewFile      = "Write to a .new file"
+	mergeSolutionOnly = "Keep the code between the markers, update the rest"
+)
+
+// askExisting asks what to do with an existing file, the diff can be viewed before choosing.
+func askExisting(relPath string, old string, content string) (string, error) {
+	options := []string{overwriteFile, showDiff, skipFile, writeNewFile}
+	if len(extractCode(old)) > 0 && len(extractCode(content)) > 0 {
+		options = append(options, mergeSolutionOnly)
+	}
+	for {
+		choice := ""
+		prompt := &survey.Select{
+			Message: fmt.Sprintf("File \"%s\" already exists:", relPath),
+			Options: options,
+		}
+		err := survey.AskOne(prompt, &choice)
+		if err != nil {
+			return "", err
+		}
+		if choice != showDiff {
+			return choice, nil
+		}
+		fmt.Println(unifiedDiff(relPath, old, content))
+	}
+}
+
 func tryWrite(file string, content string) (bool, error) {
-	write := true
 	relPath := utils.RelToCwd(file)
+	if IsDryRun() {
+		printDryRun(file, content)
+		return false, nil
+	}
 	if utils.IsExist(file) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return false, err
+		}
+		old := string(data)
+		if old == content {
+			log.Info("unchanged", "file", relPath)
+			return false, nil
+		}
 		if !viper.GetBool("yes") {
-			prompt := &survey.Confirm{Message: fmt.Sprintf("File \"%s\" already exists, overwrite?", relPath)}
-			err := survey.AskOne(prompt, &write)
+			choice, err := askExisting(relPath, old, content)
 			if err != nil {
 				return false, err
 			}
+			switch choice {
+			case skipFile:
+				return false, nil
+			case writeNewFile:
+				_, err = writeFile(file+".new", content)
+				return false, err
+			case mergeSolutionOnly:
+				content = keepSolution(old, content)
+			}
 		}
 	}
-	if !write {
-		return false, nil
-	}
+	return writeFile(file, content)
+}
+
+// keepSolution puts the solution between the code markers of the existing file into the new content,
+// everything else comes from the new content.
+func keepSolution(old string, content string) string {
+	return replaceCode(content, strings.Join(extractCode(old), "\n"))
+}
 
+func writeFile(file string, content string) (bool, error) {
 	err := utils.CreateIfNotExists(file, false)
 	if err != nil {
 		return false, err
@@ -160,7 +269,7 @@ func tryWrite(file string, content string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	log.Info("generated", "file", relPath)
+	log.Info("generated", "file", utils.RelToCwd(file))
 	return true, nil
 }
 
@@ -172,14 +281,7 @@ func GeneratePathsOnly(q *leetcode.QuestionData) (*GenerateResult, error) {
 		return nil, err
 	}
 
-	result, err := gen.GeneratePaths(q)
-	if err != nil {
-		return nil, err
-	}
-
-	outDir := getOutDir(q, gen)
-	result.SetOutDir(outDir)
-	return result, nil
+	return generateVariantPaths(gen, q, currentVariant())
 }
 
 func GetSolutionCode(q *leetcode.QuestionData) (string, error) {
@@ -195,21 +297,7 @@ func GetSolutionCode(q *leetcode.QuestionData) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	codeLines := strings.Split(code, "\n")
-	var codeLinesToKeep []string
-	inCode := false
-	for _, line := range codeLines {
-		if !inCode && strings.Contains(line, config.CodeBeginMarker) {
-			inCode = true
-			continue
-		}
-		if inCode && strings.Contains(line, config.CodeEndMarker) {
-			break
-		}
-		if inCode {
-			codeLinesToKeep = append(codeLinesToKeep, line)
-		}
-	}
+	codeLinesToKeep := extractCode(code)
 
 	nonEmptyLines := 0
 	for _, line := range codeLinesToKeep {
@@ -224,20 +312,28 @@ func GetSolutionCode(q *leetcode.QuestionData) (string, error) {
 	return strings.Join(codeLinesToKeep, "\n"), nil
 }
 
-func UpdateSolutionCode(q *leetcode.QuestionData, newCode string) error {
-	result, err := GeneratePathsOnly(q)
-	if err != nil {
-		return err
-	}
-	codeFile := result.GetFile(CodeFile)
-	if codeFile == nil {
-		return fmt.Errorf("no code file generated")
-	}
-	code, err := codeFile.GetContent()
-	if err != nil {
-		return err
+// extractCode returns the lines between CodeBeginMarker and CodeEndMarker.
+func extractCode(content string) []string {
+	var codeLines []string
+	inCode := false
+	for _, line := range strings.Split(content, "\n") {
+		if !inCode && strings.Contains(line, config.CodeBeginMarker) {
+			inCode = true
+			continue
+		}
+		if inCode && strings.Contains(line, config.CodeEndMarker) {
+			break
+		}
+		if inCode {
+			codeLines = append(codeLines, line)
+		}
 	}
-	lines := strings.Split(code, "\n")
+	return codeLines
+}
+
+// replaceCode replaces the code between CodeBeginMarker and CodeEndMarker with newCode.
+func replaceCode(content string, newCode string) string {
+	lines := strings.Split(content, "\n")
 	var newLines []string
 	skip := false
 	for _, line := range lines {
@@ -252,8 +348,27 @@ func UpdateSolutionCode(q *leetcode.QuestionData, newCode string) error {
 			newLines = append(newLines, line)
 		}
 	}
+	return strings.Join(newLines, "\n")
+}
 
-	newContent := strings.Join(newLines, "\n")
+func UpdateSolutionCode(q *leetcode.QuestionData, newCode string) error {
+	result, err := GeneratePathsOnly(q)
+	if err != nil {
+		return err
+	}
+	codeFile := result.GetFile(CodeFile)
+	if codeFile == nil {
+		return fmt.Errorf("no code file generated")
+	}
+	code, err := codeFile.GetContent()
+	if err != nil {
+		return err
+	}
+	newContent := replaceCode(code, newCode)
+	if IsDryRun() {
+		printDryRun(codeFile.GetPath(), newContent)
+		return nil
+	}
 	err = os.WriteFile(codeFile.GetPath(), []byte(newContent), 0o644)
 	if err != nil {
 		return err
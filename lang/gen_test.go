@@ -0,0 +1,107 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/j178/leetgo/utils"
+)
+
+func TestReplaceCode(t *testing.T) {
+	old := `// header
+// @lc code=begin
+
+func twoSum(nums []int, target int) []int {
+	return nil
+}
+
+// @lc code=end
+
+func main() {}
+`
+	fresh := `// new header
+// @lc code=begin
+
+func twoSum(nums []int, target int) (ans []int) {
+
+	return
+}
+
+// @lc code=end
+
+func main() { run() }
+`
+	code := strings.Join(extractCode(old), "\n")
+	got := replaceCode(fresh, code)
+	want := strings.Replace(old, "// header", "// new header", 1)
+	want = strings.Replace(want, "func main() {}", "func main() { run() }", 1)
+	if got != want {
+		t.Errorf("replaceCode() = %q, want %q", got, want)
+	}
+}
+
+func TestKeepSolution(t *testing.T) {
+	old := `// header
+// @lc code=begin
+
+func twoSum(nums []int, target int) []int {
+	seen := map[int]int{}
+	for i, x := range nums {
+		if j, ok := seen[target-x]; ok {
+			return []int{j, i}
+		}
+		seen[x] = i
+	}
+	return nil
+}
+
+// @lc code=end
+
+func main() {}
+`
+	fresh := `// new header
+// @lc code=begin
+
+func twoSum(nums []int, target int) []int {
+
+}
+
+// @lc code=end
+
+func main() { run() }
+`
+	got := keepSolution(old, fresh)
+	want := strings.Replace(old, "// header", "// new header", 1)
+	want = strings.Replace(want, "func main() {}", "func main() { run() }", 1)
+	if got != want {
+		t.Errorf("keepSolution() = %q, want %q", got, want)
+	}
+}
+
+func TestTryWrite(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "solution.go")
+	defer viper.Reset()
+
+	viper.Set("dry-run", true)
+	written, err := tryWrite(file, "package main\n")
+	if err != nil || written || utils.IsExist(file) {
+		t.Fatalf("dry-run should not write files, written=%v err=%v", written, err)
+	}
+
+	viper.Set("dry-run", false)
+	viper.Set("yes", true)
+	for _, content := range []string{"package main\n", "package main\n", "package solution\n"} {
+		_, err = tryWrite(file, content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := os.ReadFile(file)
+		if string(data) != content {
+			t.Errorf("got %q, want %q", data, content)
+		}
+	}
+}
@@ -6,96 +6,27 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/log"
+	"github.com/goccy/go-json"
+
 	"github.com/j178/leetgo/config"
 	"github.com/j178/leetgo/leetcode"
+	goutils "github.com/j178/leetgo/testutils/go"
+	"github.com/j178/leetgo/utils"
 )
 
 type golang struct {
 	baseLang
 }
 
-func addNamedReturn(code string, q *leetcode.QuestionData) string {
-	lines := strings.Split(code, "\n")
-	var newLines []string
-	skipNext := 0
-	for _, line := range lines {
-		if skipNext > 0 {
-			skipNext--
-			continue
-		}
-		if strings.HasPrefix(line, "func ") {
-			rightBrace := strings.LastIndex(line, ")")
-			returnType := strings.TrimSpace(line[rightBrace+1 : strings.LastIndex(line, "{")])
-			if returnType != "" {
-				if returnType == "bool" || returnType == "string" {
-					newLines = append(newLines, line)
-				} else if q.MetaData.SystemDesign && strings.Contains(line, "func Constructor") {
-					newLines = append(newLines, line)
-					newLines = append(newLines, "\n\treturn "+returnType+"{}")
-					skipNext = 1
-				} else {
-					newLines = append(newLines, line[:rightBrace+1]+" (ans "+returnType+") {")
-					newLines = append(newLines, "\n\treturn")
-					skipNext = 1
-				}
-			} else {
-				newLines = append(newLines, line)
-			}
-		} else {
-			newLines = append(newLines, line)
-		}
-	}
-	return strings.Join(newLines, "\n")
-}
-
-func changeReceiverName(code string, q *leetcode.QuestionData) string {
-	lines := strings.Split(code, "\n")
-	for i, line := range lines {
-		if strings.HasPrefix(line, "func (this *") {
-			n := len("func (this *")
-			prefix := strings.ToLower(line[n : n+1])
-			lines[i] = strings.Replace(line, "this", prefix, 1)
-		}
-	}
-	return strings.Join(lines, "\n")
-}
-
-func addMod(code string, q *leetcode.QuestionData) string {
-	if q.MetaData.SystemDesign {
-		return code
-	}
-	content, _ := q.GetContent()
-	if !needsMod(content) {
-		return code
-	}
-
-	lines := strings.Split(code, "\n")
-	var newLines []string
-	var returnType string
-	for _, line := range lines {
-		if strings.HasPrefix(line, "func ") {
-			if strings.Count(line, "(") == 1 {
-				rightBrace := strings.LastIndex(line, ")")
-				returnType = strings.TrimSpace(line[rightBrace+1 : strings.LastIndex(line, "{")])
-			} else {
-				s := line[strings.LastIndex(line, "(")+1 : strings.LastIndex(line, ")")]
-				returnType = s[strings.LastIndex(s, " ")+1:]
-			}
-			newLines = append(newLines, line)
-			newLines = append(newLines, "\tconst mod int = 1e9 + 7\n")
-		} else if strings.HasPrefix(line, "\treturn") {
-			if returnType == "int" || returnType == "int64" || returnType == "int32" {
-				newLines = append(newLines, "\tans = (ans%mod + mod) % mod")
-			}
-			newLines = append(newLines, line)
-		} else {
-			newLines = append(newLines, line)
-		}
-	}
-	return strings.Join(newLines, "\n")
-}
+// minGoTestUtilsVersion is the oldest testutils that the generated code works with,
+// v0.2.0 is the first release that has StartProfile.
+const minGoTestUtilsVersion = "v0.2.0"
 
 func (g golang) HasInitialized(outDir string) (bool, error) {
 	cmd := exec.Command("go", "list", "-m", "-json", config.GoTestUtilsModPath)
@@ -110,7 +41,44 @@ func (g golang) HasInitialized(outDir string) (bool, error) {
 		}
 		return false, fmt.Errorf("go list failed: %w", err)
 	}
-	return true, nil
+	var mod struct {
+		Version string
+		Replace *struct {
+			Version string
+		}
+	}
+	if err := json.Unmarshal(output, &mod); err != nil {
+		return false, fmt.Errorf("go list failed: %w", err)
+	}
+	// A local replacement has no version, it's up to the user to keep it up to date.
+	if mod.Replace != nil && mod.Replace.Version == "" {
+		return true, nil
+	}
+	if mod.Replace != nil {
+		return versionAtLeast(mod.Replace.Version, minGoTestUtilsVersion), nil
+	}
+	return versionAtLeast(mod.Version, minGoTestUtilsVersion), nil
+}
+
+// versionAtLeast reports whether the module version v is not older than min,
+// pre-releases and pseudo-versions are older than the release they precede.
+func versionAtLeast(v string, min string) bool {
+	parse := func(v string) ([3]int, bool) {
+		var nums [3]int
+		v, pre, _ := strings.Cut(strings.TrimPrefix(v, "v"), "-")
+		for i, part := range strings.SplitN(v, ".", 3) {
+			nums[i], _ = strconv.Atoi(part)
+		}
+		return nums, pre != ""
+	}
+	a, aPre := parse(v)
+	b, bPre := parse(min)
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] > b[i]
+		}
+	}
+	return !aPre || bPre
 }
 
 func (g golang) Initialize(outDir string) error {
@@ -125,7 +93,8 @@ func (g golang) Initialize(outDir string) error {
 		return err
 	}
 
-	cmd = exec.Command("go", "get", config.GoTestUtilsModPath)
+	// @latest also upgrades testutils that were added by an older leetgo.
+	cmd = exec.Command("go", "get", config.GoTestUtilsModPath+"@latest")
 	cmd.Dir = outDir
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -133,15 +102,81 @@ func (g golang) Initialize(outDir string) error {
 	return err
 }
 
-func (g golang) RunLocalTest(q *leetcode.QuestionData, outDir string) (bool, error) {
+func (g golang) RunLocalTest(q *leetcode.QuestionData, outDir string, opts ...TestOption) (bool, error) {
 	genResult, err := g.GeneratePaths(q)
 	if err != nil {
 		return false, fmt.Errorf("generate paths failed: %w", err)
 	}
+	applyVariant(genResult, newTestOptions(opts).variant)
 	genResult.SetOutDir(outDir)
+	g.addMissingImports(genResult)
 
-	args := []string{"go", "run", "./" + genResult.SubDir}
-	return runTest(q, genResult, args, outDir)
+	args := []string{"go", "run", "./" + filepath.Join(genResult.SubDir, genResult.Variant)}
+	return runTest(q, genResult, args, outDir, opts...)
+}
+
+func (g golang) Build(q *leetcode.QuestionData, outDir string, buildDir string) ([]string, error) {
+	genResult, err := g.GeneratePaths(q)
+	if err != nil {
+		return nil, fmt.Errorf("generate paths failed: %w", err)
+	}
+	genResult.SetOutDir(outDir)
+	g.addMissingImports(genResult)
+
+	bin := filepath.Join(buildDir, "solution")
+	if runtime.GOOS == "windows" {
+		bin += ".exe"
+	}
+	cmd := exec.Command("go", "build", "-o", bin, "./"+genResult.SubDir)
+	cmd.Dir = outDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("go build failed: %w\n%s", err, output)
+	}
+	return []string{bin}, nil
+}
+
+// addMissingImports adds imports of standard packages used by the solution, so that it compiles locally.
+// Failures are left to the compiler to report.
+func (g golang) addMissingImports(genResult *GenerateResult) {
+	codeFile := genResult.GetFile(CodeFile)
+	if codeFile == nil {
+		return
+	}
+	path := codeFile.GetPath()
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	code, err := addImports(string(src))
+	if err != nil || code == string(src) {
+		return
+	}
+	err = os.WriteFile(path, []byte(code), 0o644)
+	if err != nil {
+		log.Error("failed to add imports", "file", utils.RelToCwd(path), "err", err)
+		return
+	}
+	log.Info("added missing imports", "file", utils.RelToCwd(path))
+}
+
+func (g golang) ProfileEnv(kind ProfileKind, file string) []string {
+	return []string{
+		goutils.ProfileEnv + "=" + string(kind),
+		goutils.ProfileFileEnv + "=" + file,
+	}
+}
+
+func (g golang) TimingEnv(file string) []string {
+	return []string{goutils.TimingFileEnv + "=" + file}
+}
+
+func (g golang) ProfileReport(kind ProfileKind, file string) []string {
+	args := []string{"go", "tool", "pprof", "-top", "-nodecount=20"}
+	if kind == ProfileMem {
+		args = append(args, "-sample_index=alloc_space")
+	}
+	return append(args, file)
 }
 
 // convertToGoType converts LeetCode type name to Go type name.
@@ -164,8 +199,8 @@ func convertToGoType(typeName string) string {
 	case "ListNode":
 		return "*ListNode"
 	default:
-		if strings.HasSuffix(typeName, "[]") {
-			return "[]" + convertToGoType(typeName[:len(typeName)-2])
+		if e, ok := elemType(typeName); ok {
+			return "[]" + convertToGoType(e)
 		}
 	}
 	return typeName
@@ -187,18 +222,21 @@ func (g golang) generateNormalTestCode(q *leetcode.QuestionData) (string, error)
 		)
 		paramNames = append(paramNames, param.Name)
 	}
+	code += "\tstopProfile := StartProfile()\n"
 	if q.MetaData.Return != nil && q.MetaData.Return.Type != "void" {
 		code += fmt.Sprintf(
 			"\tans := %s(%s)\n",
 			q.MetaData.Name,
 			strings.Join(paramNames, ", "),
 		)
+		code += "\tstopProfile()\n"
 	} else {
 		code += fmt.Sprintf(
 			"\t%s(%s)\n",
 			q.MetaData.Name,
 			strings.Join(paramNames, ", "),
 		)
+		code += "\tstopProfile()\n"
 		ansName := paramNames[q.MetaData.Output.ParamIndex]
 		code += fmt.Sprintf("\tans := %s\n", ansName)
 	}
@@ -224,6 +262,7 @@ func (g golang) generateSystemDesignTestCode(q *leetcode.QuestionData) (string,
 	output := make([]string, 0, len(ops))
 	output = append(output, "null")
 
+	stopProfile := StartProfile()
 %s
 
 	for i := 1; i < len(ops); i++ {
@@ -231,6 +270,7 @@ func (g golang) generateSystemDesignTestCode(q *leetcode.QuestionData) (string,
 %s
 		}
 	}
+	stopProfile()
 	fmt.Println("%s " + JoinArray(output))
 }
 `
@@ -391,6 +431,7 @@ var goBuiltinModifiers = map[string]ModifierFunc{
 	"changeReceiverName":    changeReceiverName,
 	"addNamedReturn":        addNamedReturn,
 	"addMod":                addMod,
+	"sortMethods":           sortMethods,
 }
 
 func (g golang) Generate(q *leetcode.QuestionData) (*GenerateResult, error) {
@@ -406,7 +447,10 @@ func (g golang) Generate(q *leetcode.QuestionData) (*GenerateResult, error) {
 	}
 
 	separateDescriptionFile := separateDescriptionFile(g)
-	blocks := getBlocks(g)
+	blocks, err := getBlocks(g)
+	if err != nil {
+		return nil, err
+	}
 	modifiers, err := getModifiers(g, goBuiltinModifiers)
 	if err != nil {
 		return nil, err
@@ -423,7 +467,7 @@ func (g golang) Generate(q *leetcode.QuestionData) (*GenerateResult, error) {
 	genResult.AddFile(testcaseFile)
 
 	if separateDescriptionFile {
-		docFile, err := g.generateDescriptionFile(q, "question.md")
+		docFile, err := g.generateDescriptionFile(q, baseFilename, "question.md")
 		if err != nil {
 			return nil, err
 		}
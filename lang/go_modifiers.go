@@ -0,0 +1,407 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/leetcode"
+)
+
+// LeetCode Go snippets have no package clause, we add one to parse them.
+const goSnippetPackage = "package main\n"
+
+// goSnippet is a parsed Go code snippet. Modifiers use the syntax tree to locate the code to change,
+// and edit the source text directly, so that formatting and comments of the snippet are kept.
+type goSnippet struct {
+	src   string
+	fset  *token.FileSet
+	file  *ast.File
+	edits []textEdit
+}
+
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+func parseGoSnippet(code string) (*goSnippet, error) {
+	src := goSnippetPackage + code
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "solution.go", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go code: %w", err)
+	}
+	return &goSnippet{src: src, fset: fset, file: file}, nil
+}
+
+func (s *goSnippet) offset(p token.Pos) int {
+	return s.fset.Position(p).Offset
+}
+
+func (s *goSnippet) text(n ast.Node) string {
+	return s.src[s.offset(n.Pos()):s.offset(n.End())]
+}
+
+// replace replaces the source in [from, to) with text.
+func (s *goSnippet) replace(from, to token.Pos, text string) {
+	s.edits = append(s.edits, textEdit{start: s.offset(from), end: s.offset(to), text: text})
+}
+
+// insert inserts text at pos, texts inserted at the same position keep their order.
+func (s *goSnippet) insert(pos token.Pos, text string) {
+	s.replace(pos, pos, text)
+}
+
+// indentAt returns the indentation of the line containing pos.
+func (s *goSnippet) indentAt(pos token.Pos) string {
+	off := s.offset(pos)
+	lineStart := strings.LastIndexByte(s.src[:off], '\n') + 1
+	line := s.src[lineStart:off]
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// isEmptyBody reports whether the function body contains nothing but whitespace.
+func (s *goSnippet) isEmptyBody(body *ast.BlockStmt) bool {
+	if body == nil {
+		return false
+	}
+	inner := s.src[s.offset(body.Lbrace)+1 : s.offset(body.Rbrace)]
+	return strings.TrimSpace(inner) == ""
+}
+
+// String applies all edits and returns the code without the package clause.
+func (s *goSnippet) String() string {
+	return strings.TrimPrefix(s.apply(), goSnippetPackage)
+}
+
+// apply applies all edits and returns the edited source.
+func (s *goSnippet) apply() string {
+	edits := make([]textEdit, len(s.edits))
+	copy(edits, s.edits)
+	// Apply from the end, so that offsets of earlier edits are still valid.
+	sort.SliceStable(
+		edits, func(i, j int) bool {
+			return edits[i].start > edits[j].start
+		},
+	)
+	src := s.src
+	for i := 0; i < len(edits); {
+		// Edits at the same position are applied as a whole to keep their order.
+		j := i
+		var sb strings.Builder
+		for j < len(edits) && edits[j].start == edits[i].start {
+			j++
+		}
+		end := edits[i].start
+		for k := i; k < j; k++ {
+			sb.WriteString(edits[k].text)
+			if edits[k].end > end {
+				end = edits[k].end
+			}
+		}
+		src = src[:edits[i].start] + sb.String() + src[end:]
+		i = j
+	}
+	return src
+}
+
+// funcDecls returns the top-level function and method declarations.
+func (s *goSnippet) funcDecls() []*ast.FuncDecl {
+	var decls []*ast.FuncDecl
+	for _, d := range s.file.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok {
+			decls = append(decls, fn)
+		}
+	}
+	return decls
+}
+
+// modifyGoSnippet parses code, calls fn to record edits and returns the edited code.
+func modifyGoSnippet(code string, fn func(s *goSnippet)) (string, error) {
+	s, err := parseGoSnippet(code)
+	if err != nil {
+		return "", err
+	}
+	fn(s)
+	return s.String(), nil
+}
+
+func receiverTypeName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	typ := fn.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func paramNames(fn *ast.FuncDecl) map[string]bool {
+	names := make(map[string]bool)
+	for _, field := range fn.Type.Params.List {
+		for _, name := range field.Names {
+			names[name.Name] = true
+		}
+	}
+	return names
+}
+
+// changeReceiverName renames the receiver `this` to the lowercase first letter of the type.
+func changeReceiverName(code string, q *leetcode.QuestionData) (string, error) {
+	return modifyGoSnippet(
+		code, func(s *goSnippet) {
+			for _, fn := range s.funcDecls() {
+				typeName := receiverTypeName(fn)
+				if typeName == "" || len(fn.Recv.List[0].Names) == 0 {
+					continue
+				}
+				recv := fn.Recv.List[0].Names[0]
+				if recv.Name != "this" {
+					continue
+				}
+				newName := strings.ToLower(typeName[:1])
+				if paramNames(fn)[newName] {
+					newName = strings.ToLower(typeName)
+				}
+				ast.Inspect(
+					fn, func(n ast.Node) bool {
+						if ident, ok := n.(*ast.Ident); ok && ident.Name == "this" &&
+							(ident == recv || ident.Obj == recv.Obj) {
+							s.replace(ident.Pos(), ident.End(), newName)
+						}
+						return true
+					},
+				)
+			}
+		},
+	)
+}
+
+// singleResult returns the type of the only unnamed result of the function.
+func singleResult(fn *ast.FuncDecl) ast.Expr {
+	results := fn.Type.Results
+	if results == nil || len(results.List) != 1 || len(results.List[0].Names) != 0 {
+		return nil
+	}
+	return results.List[0].Type
+}
+
+// addNamedReturn names the result of functions `ans`, and adds a bare return to empty bodies,
+// so that the code compiles right after generation.
+// Constructors of system design questions return an empty struct instead.
+func addNamedReturn(code string, q *leetcode.QuestionData) (string, error) {
+	return modifyGoSnippet(
+		code, func(s *goSnippet) {
+			for _, fn := range s.funcDecls() {
+				typ := singleResult(fn)
+				if typ == nil {
+					continue
+				}
+				resultType := s.text(typ)
+				if q.MetaData.SystemDesign && fn.Recv == nil && fn.Name.Name == "Constructor" {
+					if s.isEmptyBody(fn.Body) {
+						value := resultType + "{}"
+						if star, ok := typ.(*ast.StarExpr); ok {
+							value = "&" + s.text(star.X) + "{}"
+						}
+						s.replace(fn.Body.Lbrace, fn.Body.End(), "{\n\n\treturn "+value+"\n}")
+					}
+					continue
+				}
+				if resultType == "bool" || resultType == "string" {
+					continue
+				}
+				name := "ans"
+				params := paramNames(fn)
+				for _, n := range []string{"ans", "res", "result"} {
+					if !params[n] {
+						name = n
+						break
+					}
+				}
+				s.replace(fn.Type.Results.Pos(), fn.Type.Results.End(), "("+name+" "+resultType+")")
+				if s.isEmptyBody(fn.Body) {
+					s.replace(fn.Body.Lbrace, fn.Body.End(), "{\n\n\treturn\n}")
+				}
+			}
+		},
+	)
+}
+
+var goIntTypes = map[string]bool{
+	"int":   true,
+	"int32": true,
+	"int64": true,
+}
+
+// addMod declares `const mod = 1e9 + 7` for questions that ask for the answer modulo 10^9 + 7,
+// and takes the modulo of the named result before bare returns.
+func addMod(code string, q *leetcode.QuestionData) (string, error) {
+	if q.MetaData.SystemDesign {
+		return code, nil
+	}
+	content, _ := q.GetContent()
+	if !needsMod(content) {
+		return code, nil
+	}
+	return modifyGoSnippet(
+		code, func(s *goSnippet) {
+			for _, fn := range s.funcDecls() {
+				if fn.Body == nil {
+					continue
+				}
+				s.insert(fn.Body.Lbrace+1, "\n\tconst mod = 1e9 + 7\n")
+
+				results := fn.Type.Results
+				if results == nil || len(results.List) != 1 || len(results.List[0].Names) != 1 {
+					continue
+				}
+				typ, ok := results.List[0].Type.(*ast.Ident)
+				if !ok || !goIntTypes[typ.Name] {
+					continue
+				}
+				name := results.List[0].Names[0].Name
+				ast.Inspect(
+					fn.Body, func(n ast.Node) bool {
+						switch n := n.(type) {
+						case *ast.FuncLit:
+							// Returns in closures are not returns of the function.
+							return false
+						case *ast.ReturnStmt:
+							if len(n.Results) == 0 {
+								s.insert(n.Pos(), fmt.Sprintf("%s = (%s%%mod + mod) %% mod\n%s", name, name, s.indentAt(n.Pos())))
+							}
+						}
+						return true
+					},
+				)
+			}
+		},
+	)
+}
+
+// goKnownPackages maps package names to import paths for addImports.
+// fmt, os and bufio are always imported by the generated code, so they are not listed.
+var goKnownPackages = map[string]string{
+	"sort":    "sort",
+	"strings": "strings",
+	"strconv": "strconv",
+	"math":    "math",
+	"bits":    "math/bits",
+	"rand":    "math/rand",
+	"big":     "math/big",
+	"heap":    "container/heap",
+	"list":    "container/list",
+	"bytes":   "bytes",
+	"unicode": "unicode",
+	"utf8":    "unicode/utf8",
+	"regexp":  "regexp",
+	"time":    "time",
+}
+
+// addImports adds imports of standard packages that are used by a solution file but not imported.
+// LeetCode imports them for submitted code, so they are only needed to run the file locally.
+// Imports are added to the import declaration before the code markers, or after the package clause,
+// so that they are never submitted.
+func addImports(src string) (string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "solution.go", src, parser.ParseComments)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse go code: %w", err)
+	}
+	s := &goSnippet{src: src, fset: fset, file: file}
+
+	imported := make(map[string]bool)
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		imported[path] = true
+	}
+	needed := make(map[string]bool)
+	ast.Inspect(
+		file, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			// Unresolved identifiers are not declared in the code, they may be package names.
+			if x, ok := sel.X.(*ast.Ident); ok && x.Obj == nil {
+				if path, ok := goKnownPackages[x.Name]; ok && !imported[path] {
+					needed[path] = true
+				}
+			}
+			return true
+		},
+	)
+	if len(needed) == 0 {
+		return src, nil
+	}
+	paths := make([]string, 0, len(needed))
+	for path := range needed {
+		paths = append(paths, strconv.Quote(path))
+	}
+	sort.Strings(paths)
+
+	markerOffset := strings.Index(src, config.CodeBeginMarker)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT || !gen.Rparen.IsValid() {
+			continue
+		}
+		if markerOffset >= 0 && s.offset(gen.Rparen) > markerOffset {
+			break
+		}
+		text := "\t" + strings.Join(paths, "\n\t") + "\n"
+		if off := s.offset(gen.Rparen); off > 0 && src[off-1] != '\n' {
+			text = "\n" + text
+		}
+		s.insert(gen.Rparen, text)
+		return s.apply(), nil
+	}
+	s.insert(file.Name.End(), "\n\nimport (\n\t"+strings.Join(paths, "\n\t")+"\n)")
+	return s.apply(), nil
+}
+
+// sortMethods sorts the methods of the code by name, other declarations are kept in place.
+func sortMethods(code string, q *leetcode.QuestionData) (string, error) {
+	return modifyGoSnippet(
+		code, func(s *goSnippet) {
+			var methods []*ast.FuncDecl
+			for _, fn := range s.funcDecls() {
+				if fn.Recv != nil {
+					methods = append(methods, fn)
+				}
+			}
+			sorted := make([]*ast.FuncDecl, len(methods))
+			copy(sorted, methods)
+			sort.SliceStable(
+				sorted, func(i, j int) bool {
+					return sorted[i].Name.Name < sorted[j].Name.Name
+				},
+			)
+			span := func(fn *ast.FuncDecl) (token.Pos, token.Pos) {
+				if fn.Doc != nil {
+					return fn.Doc.Pos(), fn.End()
+				}
+				return fn.Pos(), fn.End()
+			}
+			for i, fn := range methods {
+				if sorted[i] == fn {
+					continue
+				}
+				from, to := span(fn)
+				sortedFrom, sortedTo := span(sorted[i])
+				s.replace(from, to, s.src[s.offset(sortedFrom):s.offset(sortedTo)])
+			}
+		},
+	)
+}
@@ -0,0 +1,149 @@
+package lang
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"github.com/j178/leetgo/leetcode"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+// goModifierCase is a test case in testdata/go_modifiers, sections are separated by "-- name --" lines:
+// modifiers to apply, meta (question metadata in JSON), content (question description), input and output.
+type goModifierCase map[string]string
+
+var caseSections = []string{"modifiers", "meta", "content", "input", "output"}
+
+func parseGoModifierCase(data string) goModifierCase {
+	c := make(goModifierCase)
+	name := ""
+	for _, line := range strings.SplitAfter(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "-- ") && strings.HasSuffix(trimmed, " --") {
+			name = strings.TrimSuffix(strings.TrimPrefix(trimmed, "-- "), " --")
+			continue
+		}
+		c[name] += line
+	}
+	return c
+}
+
+func (c goModifierCase) String() string {
+	var sb strings.Builder
+	for _, name := range caseSections {
+		if v, ok := c[name]; ok {
+			sb.WriteString("-- " + name + " --\n" + v)
+		}
+	}
+	return sb.String()
+}
+
+func TestGoModifiers(t *testing.T) {
+	files, err := filepath.Glob("testdata/go_modifiers/*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		t.Run(
+			strings.TrimSuffix(filepath.Base(file), ".txt"), func(t *testing.T) {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				c := parseGoModifierCase(string(data))
+				q := &leetcode.QuestionData{Content: c["content"]}
+				if c["meta"] != "" {
+					err = json.Unmarshal([]byte(c["meta"]), &q.MetaData)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				code := c["input"]
+				for _, name := range strings.Fields(c["modifiers"]) {
+					m, ok := goBuiltinModifiers[name]
+					if !ok {
+						t.Fatalf("unknown modifier %s", name)
+					}
+					code, err = m(code, q)
+					if err != nil {
+						t.Fatalf("%s: %v", name, err)
+					}
+				}
+
+				if *updateGolden {
+					c["output"] = code
+					err = os.WriteFile(file, []byte(c.String()), 0o644)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+				if code != c["output"] {
+					t.Errorf("output mismatch\ngot:\n%s\nwant:\n%s", code, c["output"])
+				}
+			},
+		)
+	}
+}
+
+func TestAddImports(t *testing.T) {
+	solution := `// @lc code=begin
+
+func largestNumber(nums []int) string {
+	s := make([]string, len(nums))
+	for i, x := range nums {
+		s[i] = strconv.Itoa(x)
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i]+s[j] > s[j]+s[i] })
+	return strings.Join(s, "")
+}
+
+// @lc code=end
+`
+	tests := []struct {
+		header, want string
+	}{
+		{
+			"package main\n\nimport (\n\t\"fmt\"\n)\n\n",
+			"package main\n\nimport (\n\t\"fmt\"\n\t\"sort\"\n\t\"strconv\"\n\t\"strings\"\n)\n\n",
+		},
+		{
+			"package main\n\nimport (\"fmt\")\n\n",
+			"package main\n\nimport (\"fmt\"\n\t\"sort\"\n\t\"strconv\"\n\t\"strings\"\n)\n\n",
+		},
+		{
+			"package main\n\n",
+			"package main\n\nimport (\n\t\"sort\"\n\t\"strconv\"\n\t\"strings\"\n)\n\n",
+		},
+	}
+	for _, tc := range tests {
+		got, err := addImports(tc.header + solution)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want+solution {
+			t.Errorf("got:\n%s\nwant:\n%s", got, tc.want+solution)
+		}
+		if extractCode(got)[1] != "func largestNumber(nums []int) string {" {
+			t.Errorf("the code between the markers should be kept, got:\n%s", got)
+		}
+	}
+
+	// An import declaration between the markers is submitted, it's left alone.
+	src := "package main\n\n// @lc code=begin\n\nimport (\n\t\"fmt\"\n)\n\nfunc f() { fmt.Println(strings.ToUpper(\"\")) }\n\n// @lc code=end\n"
+	got, err := addImports(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package main\n\nimport (\n\t\"strings\"\n)\n\n// @lc code=begin\n\nimport (\n\t\"fmt\"\n)\n\nfunc f() { fmt.Println(strings.ToUpper(\"\")) }\n\n// @lc code=end\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
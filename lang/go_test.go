@@ -0,0 +1,24 @@
+package lang
+
+import "testing"
+
+func TestVersionAtLeast(t *testing.T) {
+	testCases := []struct {
+		v    string
+		want bool
+	}{
+		{"v0.1.0", false},
+		{"v0.1.0-alpha", false},
+		{"v0.0.0-20230301000000-abcdefabcdef", false},
+		{"v0.2.0-rc.1", false},
+		{"v0.2.0", true},
+		{"v0.2.1-0.20230301000000-abcdefabcdef", true},
+		{"v0.10.0", true},
+		{"v1.0.0", true},
+	}
+	for _, tc := range testCases {
+		if got := versionAtLeast(tc.v, "v0.2.0"); got != tc.want {
+			t.Errorf("versionAtLeast(%q, v0.2.0) = %v, want %v", tc.v, got, tc.want)
+		}
+	}
+}
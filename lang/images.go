@@ -0,0 +1,162 @@
+package lang
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/utils"
+)
+
+const assetsDir = "assets"
+
+// imageAsset is a remote image of the question description that is saved locally.
+type imageAsset struct {
+	URL  string
+	Path string
+}
+
+// markdownImageRe matches images in the formatted description, e.g. ![alt](https://... "title").
+// Alt text may be wrapped to several lines.
+var markdownImageRe = regexp.MustCompile(`!\[([^\]]*)\]\((https?://[^\s)]+)(?:\s+"[^"]*")?\)`)
+
+// imageFilename names the local copy of an image by the hash of its URL,
+// so that the same image is downloaded only once.
+func imageFilename(rawURL string) string {
+	sum := sha1.Sum([]byte(rawURL))
+	ext := ""
+	if u, err := url.Parse(rawURL); err == nil {
+		ext = strings.ToLower(path.Ext(u.Path))
+	}
+	if ext == "" || len(ext) > 5 {
+		ext = ".png"
+	}
+	return hex.EncodeToString(sum[:8]) + ext
+}
+
+// imagesAssetsDir returns the assets folder of the question, it's next to the description file,
+// or next to the code file if the description is in the code.
+func imagesAssetsDir(result *GenerateResult) string {
+	if f := result.GetFile(DocFile); f != nil {
+		return filepath.Join(filepath.Dir(f.GetPath()), assetsDir)
+	}
+	f := result.GetFile(CodeFile)
+	if f == nil {
+		return ""
+	}
+	dir := filepath.Dir(f.GetPath())
+	// Variants of languages that generate a directory per question live in a subdirectory.
+	if result.Variant != "" && result.SubDir != "" {
+		dir = filepath.Dir(dir)
+	}
+	return filepath.Join(dir, assetsDir)
+}
+
+// localizeImages rewrites remote images in the description and code files of result to their local copies,
+// and returns the images to download for each file.
+// In the description file images are linked to the local copies; in code comments,
+// which can't show images, they are replaced by the alt text and the local path.
+// Nothing is changed unless code.download_images is enabled.
+func localizeImages(result *GenerateResult) map[string][]imageAsset {
+	if !config.Get().Code.DownloadImages {
+		return nil
+	}
+	return rewriteImages(result)
+}
+
+func rewriteImages(result *GenerateResult) map[string][]imageAsset {
+	dir := imagesAssetsDir(result)
+	if dir == "" {
+		return nil
+	}
+
+	assets := make(map[string][]imageAsset)
+	for i, f := range result.Files {
+		if f.Type&(DocFile|CodeFile) == 0 {
+			continue
+		}
+		filePath := f.GetPath()
+		result.Files[i].Content = markdownImageRe.ReplaceAllStringFunc(
+			f.Content, func(s string) string {
+				m := markdownImageRe.FindStringSubmatch(s)
+				alt, src := strings.Join(strings.Fields(m[1]), " "), m[2]
+				asset := imageAsset{URL: src, Path: filepath.Join(dir, imageFilename(src))}
+				assets[filePath] = append(assets[filePath], asset)
+
+				rel, err := filepath.Rel(filepath.Dir(filePath), asset.Path)
+				if err != nil {
+					rel = asset.Path
+				}
+				rel = filepath.ToSlash(rel)
+				if f.Type&DocFile != 0 {
+					return fmt.Sprintf("![%s](%s)", alt, rel)
+				}
+				if alt == "" {
+					alt = "image"
+				}
+				return fmt.Sprintf("[%s](%s)", alt, rel)
+			},
+		)
+	}
+	return assets
+}
+
+var imageClient = &http.Client{Timeout: 30 * time.Second}
+
+// downloadImages saves the images that are not downloaded yet, failures are logged and skipped.
+func downloadImages(assets []imageAsset) {
+	for _, a := range assets {
+		if utils.IsExist(a.Path) {
+			continue
+		}
+		err := downloadImage(a)
+		if err != nil {
+			log.Warn("failed to download image", "url", a.URL, "err", err)
+			continue
+		}
+		log.Debug("downloaded image", "url", a.URL, "file", utils.RelToCwd(a.Path))
+	}
+}
+
+func downloadImage(a imageAsset) error {
+	resp, err := imageClient.Get(a.URL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	err = utils.CreateIfNotExists(filepath.Dir(a.Path), true)
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first, so that a broken download is not taken as cached.
+	tmp := a.Path + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, resp.Body)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, a.Path)
+}
@@ -0,0 +1,39 @@
+package lang
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRewriteImages(t *testing.T) {
+	const img = "https://assets.leetcode.com/uploads/2020/10/03/tree.jpg"
+	content := "Example 1:\n\n![binary\ntree](" + img + " \"tree\")\n"
+	name := imageFilename(img)
+	if !strings.HasSuffix(name, ".jpg") || name != imageFilename(img) {
+		t.Fatalf("unexpected image filename: %s", name)
+	}
+
+	result := &GenerateResult{OutDir: "go", SubDir: "0100.same-tree", Variant: "dfs"}
+	result.AddFile(FileOutput{Filename: "dfs/solution.go", Type: CodeFile, Content: "/*\n" + content + "*/\n"})
+	images := rewriteImages(result)
+
+	want := "/*\nExample 1:\n\n[binary tree](../assets/" + name + ")\n*/\n"
+	if got := result.Files[0].Content; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	assets := images[result.Files[0].GetPath()]
+	if len(assets) != 1 || assets[0].URL != img ||
+		assets[0].Path != filepath.Join("go", "0100.same-tree", "assets", name) {
+		t.Errorf("unexpected assets: %v", assets)
+	}
+
+	result = &GenerateResult{OutDir: "cpp"}
+	result.AddFile(FileOutput{Filename: "0100.same-tree.cpp", Type: CodeFile, Content: "// code\n"})
+	result.AddFile(FileOutput{Filename: "0100.same-tree.md", Type: DocFile, Content: content})
+	rewriteImages(result)
+	want = "Example 1:\n\n![binary tree](assets/" + name + ")\n"
+	if got := result.Files[1].Content; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,143 @@
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/leetcode"
+	"github.com/j178/leetgo/utils"
+)
+
+var (
+	// ErrUnknownSolution is returned when the question or language of a file can't be detected.
+	ErrUnknownSolution = errors.New("not a known solution file")
+	// ErrAlreadyGenerated is returned when importing a solution of a question that has been generated.
+	ErrAlreadyGenerated = errors.New("solution file already exists")
+)
+
+// ImportedSolution is a solution written with other tools, like vscode-leetcode or leetcode-cli.
+type ImportedSolution struct {
+	Path     string
+	Question *leetcode.QuestionData
+	Lang     Lang
+	Code     string
+}
+
+var (
+	lcHeaderPat       = regexp.MustCompile(`@lc app=\S+ id=(\S+) lang=(\S+)`)
+	importFilenamePat = regexp.MustCompile(`^(\d+)\.([\w-]+)\.\w+$`)
+)
+
+// Markers of other tools, vscode-leetcode uses "code=start" rather than "code=begin".
+var importBeginMarkers = []string{config.CodeBeginMarker, "@lc code=start"}
+
+// ParseImportedSolution detects the question and language of a solution file written by other tools,
+// from its `@lc app=... id=... lang=...` header, or from its filename like "1.two-sum.go".
+func ParseImportedSolution(path string, c leetcode.Client) (*ImportedSolution, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	content := string(data)
+	s := &ImportedSolution{Path: path}
+
+	if m := lcHeaderPat.FindStringSubmatch(content); m != nil {
+		s.Question, err = leetcode.QuestionFromCacheByID(m[1], c)
+		if err != nil {
+			return nil, fmt.Errorf("%w: question %s not found", ErrUnknownSolution, m[1])
+		}
+		s.Lang, err = GetGenerator(m[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSolution, err)
+		}
+	} else if m := importFilenamePat.FindStringSubmatch(filepath.Base(path)); m != nil {
+		s.Question, err = leetcode.QuestionFromCacheBySlug(m[2], c)
+		if err != nil {
+			s.Question, err = leetcode.QuestionFromCacheByID(m[1], c)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: question %s not found", ErrUnknownSolution, m[2])
+		}
+		s.Lang = langByExtension(s.Question, filepath.Ext(path))
+		if s.Lang == nil {
+			return nil, fmt.Errorf("%w: unsupported extension %s", ErrUnknownSolution, filepath.Ext(path))
+		}
+	} else {
+		return nil, ErrUnknownSolution
+	}
+
+	s.Code = extractImportedCode(content)
+	return s, nil
+}
+
+// langByExtension finds the language whose code file of q has the extension.
+func langByExtension(q *leetcode.QuestionData, ext string) Lang {
+	for _, l := range allLangs() {
+		paths, err := l.GeneratePaths(q)
+		if err != nil {
+			continue
+		}
+		if f := paths.GetFile(CodeFile); f != nil && filepath.Ext(f.Filename) == ext {
+			return l
+		}
+	}
+	return nil
+}
+
+// extractImportedCode returns the code between the code markers, or the whole file if there are no markers.
+func extractImportedCode(content string) string {
+	lines := strings.Split(content, "\n")
+	begin, end := -1, len(lines)
+	for i, line := range lines {
+		if begin < 0 {
+			for _, marker := range importBeginMarkers {
+				if strings.Contains(line, marker) {
+					begin = i
+					break
+				}
+			}
+		} else if strings.Contains(line, config.CodeEndMarker) {
+			end = i
+			break
+		}
+	}
+	if begin < 0 {
+		return strings.Trim(content, "\n")
+	}
+	return strings.Trim(strings.Join(lines[begin+1:end], "\n"), "\n")
+}
+
+// ImportSolution generates leetgo files of the imported solution, and puts its code between the code markers.
+// Solutions of questions that have been generated are not imported.
+func ImportSolution(s *ImportedSolution) (*GenerateResult, error) {
+	cfg := config.Get()
+	primary := cfg.Code.Lang
+	defer func() { cfg.Code.Lang = primary }()
+	cfg.Code.Lang = s.Lang.Slug()
+
+	paths, err := GeneratePathsOnly(s.Question)
+	if err != nil {
+		return nil, err
+	}
+	if f := paths.GetFile(CodeFile); f != nil && utils.IsExist(f.GetPath()) {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyGenerated, utils.RelToCwd(f.GetPath()))
+	}
+
+	result, err := Generate(s.Question)
+	if err != nil {
+		return nil, err
+	}
+	if IsDryRun() {
+		return result, nil
+	}
+	err = UpdateSolutionCode(s.Question, s.Code)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,32 @@
+package lang
+
+import "testing"
+
+func TestExtractImportedCode(t *testing.T) {
+	vscode := `/*
+ * @lc app=leetcode.cn id=1 lang=golang
+ *
+ * [1] 两数之和
+ */
+
+// @lc code=start
+func twoSum(nums []int, target int) []int {
+	return nil
+}
+
+// @lc code=end
+`
+	want := "func twoSum(nums []int, target int) []int {\n\treturn nil\n}"
+	if got := extractImportedCode(vscode); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	m := lcHeaderPat.FindStringSubmatch(vscode)
+	if m == nil || m[1] != "1" || m[2] != "golang" {
+		t.Errorf("unexpected header match: %v", m)
+	}
+
+	plain := "\nclass Solution:\n    pass\n"
+	if got := extractImportedCode(plain); got != "class Solution:\n    pass" {
+		t.Errorf("code without markers should be kept, got %q", got)
+	}
+}
@@ -0,0 +1,233 @@
+package lang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/goccy/go-json"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/leetcode"
+)
+
+// questionMetaFile is written into question directories at generation time,
+// so that the question of the directory can be found without a qid.
+const questionMetaFile = ".leetgo.json"
+
+type questionMeta struct {
+	Slug string `json:"slug"`
+	ID   string `json:"id"`
+	Lang string `json:"lang"`
+}
+
+// writeQuestionMeta writes the metadata file of languages that generate a directory per question.
+func writeQuestionMeta(result *GenerateResult) error {
+	if result.SubDir == "" {
+		return nil
+	}
+	data, err := json.Marshal(
+		questionMeta{
+			Slug: result.Question.TitleSlug,
+			ID:   result.Question.QuestionFrontendId,
+			Lang: result.Lang.Slug(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(result.OutDir, result.SubDir, questionMetaFile), data, 0o644)
+}
+
+func readQuestionMeta(dir string) *questionMeta {
+	data, err := os.ReadFile(filepath.Join(dir, questionMetaFile))
+	if err != nil {
+		return nil
+	}
+	var meta questionMeta
+	if json.Unmarshal(data, &meta) != nil || meta.Slug == "" {
+		return nil
+	}
+	return &meta
+}
+
+// InferredSolution is the solution that a file or directory belongs to.
+type InferredSolution struct {
+	Question *leetcode.QuestionData
+	Lang     Lang
+	Variant  string
+}
+
+// InferSolution finds the question and language of a code file or a question directory, by:
+//  1. the question URL in the header of the code file,
+//  2. the metadata file of the question directory,
+//  3. reverse-mapping the path through the filename template,
+//  4. the question URL in the header of code files in the directory.
+//
+// The language comes from the extension of the code file.
+func InferSolution(path string, c leetcode.Client) (*InferredSolution, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	dir, file := path, ""
+	if !info.IsDir() {
+		dir, file = filepath.Dir(path), path
+	}
+
+	if file != "" {
+		if q, _ := questionFromHeader(file, c); q != nil {
+			if s := inferFromFile(q, file); s != nil {
+				return s, nil
+			}
+		}
+	}
+
+	// Variants live in subdirectories of the question directory.
+	for i, d := range []string{dir, filepath.Dir(dir)} {
+		meta := readQuestionMeta(d)
+		if meta == nil {
+			continue
+		}
+		q, err := leetcode.QuestionBySlug(meta.Slug, c)
+		if err != nil {
+			return nil, err
+		}
+		gen, err := GetGenerator(meta.Lang)
+		if err != nil {
+			return nil, err
+		}
+		variant := ""
+		if i > 0 {
+			variant = filepath.Base(dir)
+		}
+		return &InferredSolution{Question: q, Lang: gen, Variant: variant}, nil
+	}
+
+	if s := inferFromPath(dir, file, c); s != nil {
+		return s, nil
+	}
+
+	if file == "" {
+		entries, _ := os.ReadDir(dir)
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			f := filepath.Join(dir, e.Name())
+			if q, _ := questionFromHeader(f, c); q != nil {
+				if s := inferFromFile(q, f); s != nil {
+					return s, nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("cannot find the question of %s, please specify a qid", path)
+}
+
+// inferFromFile finds the language of the code file of q by its extension.
+func inferFromFile(q *leetcode.QuestionData, file string) *InferredSolution {
+	gen := langByExtension(q, filepath.Ext(file))
+	if gen == nil {
+		return nil
+	}
+	s := &InferredSolution{Question: q, Lang: gen}
+	if paths, err := gen.GeneratePaths(q); err == nil {
+		paths.SetOutDir(getOutDir(q, gen))
+		s.Variant, _ = matchSolutionPath(paths, filepath.Dir(file), file)
+	}
+	return s
+}
+
+var pathTokenPat = regexp.MustCompile(`[a-z0-9]+(?:-[a-z0-9]+)*`)
+
+// inferFromPath reverse-maps the path through the filename template: questions named by the IDs and slugs
+// in the path are looked up in the cache, and the one whose generated path is the given path is returned.
+func inferFromPath(dir string, file string, c leetcode.Client) *InferredSolution {
+	rel, err := filepath.Rel(config.Get().ProjectRoot(), dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil
+	}
+	if file != "" {
+		rel = filepath.Join(rel, filepath.Base(file))
+	}
+
+	seen := make(map[string]bool)
+	for _, token := range pathTokenPat.FindAllString(strings.ToLower(rel), -1) {
+		var q *leetcode.QuestionData
+		if isNumber(token) {
+			q, _ = leetcode.QuestionFromCacheByID(strings.TrimLeft(token, "0"), c)
+		} else {
+			q, _ = leetcode.QuestionFromCacheBySlug(token, c)
+		}
+		if q == nil || seen[q.TitleSlug] {
+			continue
+		}
+		seen[q.TitleSlug] = true
+		for _, gen := range allLangs() {
+			paths, err := gen.GeneratePaths(q)
+			if err != nil {
+				continue
+			}
+			paths.SetOutDir(getOutDir(q, gen))
+			if variant, ok := matchSolutionPath(paths, dir, file); ok {
+				return &InferredSolution{Question: q, Lang: gen, Variant: variant}
+			}
+		}
+	}
+	return nil
+}
+
+// matchSolutionPath reports whether the file, or the directory if file is empty, belongs to the solution,
+// and returns the variant it belongs to.
+func matchSolutionPath(paths *GenerateResult, dir string, file string) (string, bool) {
+	code := paths.GetFile(CodeFile)
+	if code == nil {
+		return "", false
+	}
+	codePath := code.GetPath()
+	if paths.SubDir != "" {
+		// Any file in the question directory belongs to the question, e.g. testcases.txt.
+		questionDir := filepath.Dir(codePath)
+		switch {
+		case dir == questionDir:
+			return "", true
+		case filepath.Dir(dir) == questionDir:
+			return filepath.Base(dir), true
+		}
+		return "", false
+	}
+
+	if file == "" || filepath.Ext(file) != filepath.Ext(codePath) {
+		return "", false
+	}
+	if file == codePath {
+		return "", true
+	}
+	ext := filepath.Ext(codePath)
+	prefix := strings.TrimSuffix(filepath.Base(codePath), ext) + "."
+	name := strings.TrimSuffix(filepath.Base(file), ext)
+	if !strings.HasPrefix(name, prefix) {
+		return "", false
+	}
+	variant := strings.TrimPrefix(name, prefix)
+	if file != filepath.Join(paths.OutDir, paths.SubDir, variantFilename(paths, code.Filename, variant)) {
+		return "", false
+	}
+	return variant, true
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,42 @@
+package lang
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchSolutionPath(t *testing.T) {
+	dirPaths := &GenerateResult{OutDir: "/p/go", SubDir: "0322.coin-change"}
+	dirPaths.AddFile(FileOutput{Filename: "solution.go", Type: CodeFile})
+	flatPaths := &GenerateResult{OutDir: "/p/cpp"}
+	flatPaths.AddFile(FileOutput{Filename: "0322.coin-change.cpp", Type: CodeFile})
+
+	testCases := []struct {
+		paths   *GenerateResult
+		path    string
+		isFile  bool
+		variant string
+		ok      bool
+	}{
+		{dirPaths, "/p/go/0322.coin-change", false, "", true},
+		{dirPaths, "/p/go/0322.coin-change/testcases.txt", true, "", true},
+		{dirPaths, "/p/go/0322.coin-change/dp", false, "dp", true},
+		{dirPaths, "/p/go/0322.coin-change/dp/solution.go", true, "dp", true},
+		{dirPaths, "/p/go/0001.two-sum", false, "", false},
+		{flatPaths, "/p/cpp/0322.coin-change.cpp", true, "", true},
+		{flatPaths, "/p/cpp/0322.coin-change.greedy.cpp", true, "greedy", true},
+		{flatPaths, "/p/cpp/0322.coin-change.md", true, "", false},
+		{flatPaths, "/p/cpp", false, "", false},
+	}
+	for _, tc := range testCases {
+		path := filepath.FromSlash(tc.path)
+		dir, file := path, ""
+		if tc.isFile {
+			dir, file = filepath.Dir(path), path
+		}
+		variant, ok := matchSolutionPath(tc.paths, dir, file)
+		if variant != tc.variant || ok != tc.ok {
+			t.Errorf("%s: got (%q, %v), want (%q, %v)", tc.path, variant, ok, tc.variant, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package lang
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/leetcode"
+)
+
+type java struct {
+	baseLang
+}
+
+const javaSettingsGradle = `rootProject.name = 'leetcode-solutions'
+`
+
+const javaBuildGradle = `plugins {
+    id 'java'
+}
+
+java {
+    sourceCompatibility = JavaVersion.VERSION_17
+}
+
+// Every solution is placed in the directory of its own package.
+sourceSets {
+    main {
+        java {
+            srcDirs = ['.']
+            exclude 'build/**', '.gradle/**'
+        }
+    }
+}
+`
+
+const javaListNode = `// Code generated by leetgo. DO NOT EDIT.
+package leetcode;
+
+public class ListNode {
+    public int val;
+    public ListNode next;
+
+    public ListNode() {}
+
+    public ListNode(int val) { this.val = val; }
+
+    public ListNode(int val, ListNode next) { this.val = val; this.next = next; }
+}
+`
+
+const javaTreeNode = `// Code generated by leetgo. DO NOT EDIT.
+package leetcode;
+
+public class TreeNode {
+    public int val;
+    public TreeNode left;
+    public TreeNode right;
+
+    public TreeNode() {}
+
+    public TreeNode(int val) { this.val = val; }
+
+    public TreeNode(int val, TreeNode left, TreeNode right) {
+        this.val = val;
+        this.left = left;
+        this.right = right;
+    }
+}
+`
+
+// solutionPackage returns the package of the main solution, which is also the directory of its code file.
+// Every solution has its own package, so classes named Solution in different files don't clash.
+func solutionPackage(q *leetcode.QuestionData, l Lang) (string, error) {
+	filenameTmpl := getFilenameTemplate(q, l)
+	baseFilename, err := q.GetFormattedFilename(l.Slug(), filenameTmpl)
+	if err != nil {
+		return "", err
+	}
+	return toIdentifier(baseFilename), nil
+}
+
+// variantPackage returns the package of a variant, e.g. "q0001_two_sum_dp".
+func variantPackage(pkg string, variant string) string {
+	if variant == "" {
+		return pkg
+	}
+	return pkg + "_" + strings.ToLower(strings.ReplaceAll(variant, "-", "_"))
+}
+
+// packageVariantFilename moves the code file of a variant into the directory of the variant package,
+// e.g. "q0001_two_sum/0001.two-sum.java" becomes "q0001_two_sum_dp/0001.two-sum.dp.java".
+func packageVariantFilename(filename string, variant string) string {
+	dir, name := filepath.Split(filename)
+	ext := filepath.Ext(name)
+	return filepath.Join(
+		variantPackage(filepath.Clean(dir), variant),
+		strings.TrimSuffix(name, ext)+"."+variant+ext,
+	)
+}
+
+// placeInPackage puts the code file of the result into the directory of its package,
+// so that the layout matches the package declaration.
+func placeInPackage(result *GenerateResult, pkg string) {
+	for i, f := range result.Files {
+		if f.Type&CodeFile != 0 {
+			result.Files[i].Filename = filepath.Join(pkg, filepath.Base(f.Filename))
+		}
+	}
+}
+
+func (j java) GeneratePaths(q *leetcode.QuestionData) (*GenerateResult, error) {
+	pkg, err := solutionPackage(q, j)
+	if err != nil {
+		return nil, err
+	}
+	result, err := j.baseLang.GeneratePaths(q)
+	if err != nil {
+		return nil, err
+	}
+	placeInPackage(result, pkg)
+	result.Lang = j
+	return result, nil
+}
+
+// Generate declares the package of the selected variant, applyVariant moves the file into its directory later.
+func (j java) Generate(q *leetcode.QuestionData) (*GenerateResult, error) {
+	pkg, err := solutionPackage(q, j)
+	if err != nil {
+		return nil, err
+	}
+	declared := variantPackage(pkg, currentVariant())
+	result, err := j.generateFiles(
+		q, config.Block{
+			Name:     internalBeforeMarker,
+			Template: fmt.Sprintf("package %s;\n\nimport java.util.*;\nimport leetcode.*;\n", declared),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	placeInPackage(result, pkg)
+	result.Lang = j
+	return result, nil
+}
+
+func (j java) variantFilename(filename string, variant string) string {
+	return packageVariantFilename(filename, variant)
+}
+
+func (j java) scaffoldFiles() []scaffoldFile {
+	return []scaffoldFile{
+		{Path: "settings.gradle", Content: javaSettingsGradle},
+		{Path: "build.gradle", Content: javaBuildGradle},
+		{Path: "leetcode/ListNode.java", Content: javaListNode, Managed: true},
+		{Path: "leetcode/TreeNode.java", Content: javaTreeNode, Managed: true},
+	}
+}
+
+func (j java) HasInitialized(outDir string) (bool, error) {
+	return hasScaffold(outDir, j.scaffoldFiles())
+}
+
+func (j java) Initialize(outDir string) error {
+	return writeScaffold(outDir, j.scaffoldFiles())
+}
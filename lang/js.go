@@ -0,0 +1,90 @@
+package lang
+
+type javascript struct {
+	baseLang
+}
+
+type typescript struct {
+	baseLang
+}
+
+const jsPackageJSON = `{
+  "name": "leetcode-solutions",
+  "version": "0.1.0",
+  "private": true
+}
+`
+
+// Every solution is treated as a module, so functions with the same name in different files don't clash.
+const jsConfigJSON = `{
+  "compilerOptions": {
+    "target": "ES2022",
+    "moduleDetection": "force",
+    "checkJs": false
+  },
+  "include": ["*.js", "*.d.ts"]
+}
+`
+
+const tsConfigJSON = `{
+  "compilerOptions": {
+    "target": "ES2022",
+    "lib": ["ES2022"],
+    "moduleDetection": "force",
+    "strict": true,
+    "noEmit": true,
+    "skipLibCheck": true
+  },
+  "include": ["*.ts"]
+}
+`
+
+const jsTypesFile = "leetcode.d.ts"
+
+// jsTypes declares the data structures LeetCode provides as globals.
+const jsTypes = `// Code generated by leetgo. DO NOT EDIT.
+declare class ListNode {
+  val: number
+  next: ListNode | null
+  constructor(val?: number, next?: ListNode | null)
+}
+
+declare class TreeNode {
+  val: number
+  left: TreeNode | null
+  right: TreeNode | null
+  constructor(val?: number, left?: TreeNode | null, right?: TreeNode | null)
+}
+`
+
+func (j javascript) scaffoldFiles() []scaffoldFile {
+	return []scaffoldFile{
+		{Path: "package.json", Content: jsPackageJSON},
+		{Path: "jsconfig.json", Content: jsConfigJSON},
+		{Path: jsTypesFile, Content: jsTypes, Managed: true},
+	}
+}
+
+func (j javascript) HasInitialized(outDir string) (bool, error) {
+	return hasScaffold(outDir, j.scaffoldFiles())
+}
+
+func (j javascript) Initialize(outDir string) error {
+	return writeScaffold(outDir, j.scaffoldFiles())
+}
+
+func (t typescript) scaffoldFiles() []scaffoldFile {
+	return []scaffoldFile{
+		{Path: "package.json", Content: jsPackageJSON},
+		{Path: "tsconfig.json", Content: tsConfigJSON},
+		{Path: jsTypesFile, Content: jsTypes, Managed: true},
+	}
+}
+
+func (t typescript) HasInitialized(outDir string) (bool, error) {
+	return hasScaffold(outDir, t.scaffoldFiles())
+}
+
+func (t typescript) Initialize(outDir string) error {
+	return writeScaffold(outDir, t.scaffoldFiles())
+}
@@ -0,0 +1,101 @@
+package lang
+
+import (
+	"fmt"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/leetcode"
+)
+
+type kotlin struct {
+	baseLang
+}
+
+const kotlinSettingsGradle = `rootProject.name = "leetcode-solutions"
+`
+
+const kotlinBuildGradle = `plugins {
+    kotlin("jvm") version "1.9.22"
+}
+
+repositories {
+    mavenCentral()
+}
+
+// Every solution is placed in the directory of its own package.
+sourceSets {
+    main {
+        kotlin {
+            setSrcDirs(listOf("."))
+            exclude("build/**", ".gradle/**")
+        }
+    }
+}
+`
+
+const kotlinDataStructures = `// Code generated by leetgo. DO NOT EDIT.
+package leetcode
+
+class ListNode(var ` + "`val`" + `: Int) {
+    var next: ListNode? = null
+}
+
+class TreeNode(var ` + "`val`" + `: Int) {
+    var left: TreeNode? = null
+    var right: TreeNode? = null
+}
+`
+
+func (k kotlin) GeneratePaths(q *leetcode.QuestionData) (*GenerateResult, error) {
+	pkg, err := solutionPackage(q, k)
+	if err != nil {
+		return nil, err
+	}
+	result, err := k.baseLang.GeneratePaths(q)
+	if err != nil {
+		return nil, err
+	}
+	placeInPackage(result, pkg)
+	result.Lang = k
+	return result, nil
+}
+
+func (k kotlin) Generate(q *leetcode.QuestionData) (*GenerateResult, error) {
+	pkg, err := solutionPackage(q, k)
+	if err != nil {
+		return nil, err
+	}
+	declared := variantPackage(pkg, currentVariant())
+	result, err := k.generateFiles(
+		q, config.Block{
+			Name:     internalBeforeMarker,
+			Template: fmt.Sprintf("package %s\n\nimport leetcode.*\n", declared),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	placeInPackage(result, pkg)
+	result.Lang = k
+	return result, nil
+}
+
+func (k kotlin) variantFilename(filename string, variant string) string {
+	return packageVariantFilename(filename, variant)
+}
+
+func (k kotlin) scaffoldFiles() []scaffoldFile {
+	return []scaffoldFile{
+		{Path: "settings.gradle.kts", Content: kotlinSettingsGradle},
+		{Path: "build.gradle.kts", Content: kotlinBuildGradle},
+		{Path: "leetcode/DataStructures.kt", Content: kotlinDataStructures, Managed: true},
+	}
+}
+
+func (k kotlin) HasInitialized(outDir string) (bool, error) {
+	return hasScaffold(outDir, k.scaffoldFiles())
+}
+
+func (k kotlin) Initialize(outDir string) error {
+	return writeScaffold(outDir, k.scaffoldFiles())
+}
@@ -23,32 +23,38 @@ var (
 			blockCommentEnd:   `"""`,
 		},
 	}
-	cppGen = baseLang{
-		name:              "C++",
-		slug:              "cpp",
-		shortName:         "cpp",
-		extension:         ".cpp",
-		lineComment:       "//",
-		blockCommentStart: "/*",
-		blockCommentEnd:   "*/",
+	cppGen = cpp{
+		baseLang{
+			name:              "C++",
+			slug:              "cpp",
+			shortName:         "cpp",
+			extension:         ".cpp",
+			lineComment:       "//",
+			blockCommentStart: "/*",
+			blockCommentEnd:   "*/",
+		},
 	}
-	rustGen = baseLang{
-		name:              "Rust",
-		slug:              "rust",
-		shortName:         "rs",
-		extension:         ".rs",
-		lineComment:       "//",
-		blockCommentStart: "/*",
-		blockCommentEnd:   "*/",
+	rustGen = rust{
+		baseLang{
+			name:              "Rust",
+			slug:              "rust",
+			shortName:         "rs",
+			extension:         ".rs",
+			lineComment:       "//",
+			blockCommentStart: "/*",
+			blockCommentEnd:   "*/",
+		},
 	}
-	javaGen = baseLang{
-		name:              "Java",
-		slug:              "java",
-		shortName:         "java",
-		extension:         ".java",
-		lineComment:       "//",
-		blockCommentStart: "/*",
-		blockCommentEnd:   "*/",
+	javaGen = java{
+		baseLang{
+			name:              "Java",
+			slug:              "java",
+			shortName:         "java",
+			extension:         ".java",
+			lineComment:       "//",
+			blockCommentStart: "/*",
+			blockCommentEnd:   "*/",
+		},
 	}
 	cGen = baseLang{
 		name:              "C",
@@ -68,23 +74,27 @@ var (
 		blockCommentStart: "/*",
 		blockCommentEnd:   "*/",
 	}
-	jsGen = baseLang{
-		name:              "JavaScript",
-		slug:              "javascript",
-		shortName:         "js",
-		extension:         ".js",
-		lineComment:       "//",
-		blockCommentStart: "/*",
-		blockCommentEnd:   "*/",
+	jsGen = javascript{
+		baseLang{
+			name:              "JavaScript",
+			slug:              "javascript",
+			shortName:         "js",
+			extension:         ".js",
+			lineComment:       "//",
+			blockCommentStart: "/*",
+			blockCommentEnd:   "*/",
+		},
 	}
-	tsGen = baseLang{
-		name:              "TypeScript",
-		slug:              "typescript",
-		shortName:         "ts",
-		extension:         ".ts",
-		lineComment:       "//",
-		blockCommentStart: "/*",
-		blockCommentEnd:   "*/",
+	tsGen = typescript{
+		baseLang{
+			name:              "TypeScript",
+			slug:              "typescript",
+			shortName:         "ts",
+			extension:         ".ts",
+			lineComment:       "//",
+			blockCommentStart: "/*",
+			blockCommentEnd:   "*/",
+		},
 	}
 	phpGen = baseLang{
 		name:              "PHP",
@@ -113,14 +123,16 @@ var (
 		blockCommentStart: "/*",
 		blockCommentEnd:   "*/",
 	}
-	kotlinGen = baseLang{
-		name:              "Kotlin",
-		slug:              "kotlin",
-		shortName:         "kt",
-		extension:         ".kt",
-		lineComment:       "//",
-		blockCommentStart: "/*",
-		blockCommentEnd:   "*/",
+	kotlinGen = kotlin{
+		baseLang{
+			name:              "Kotlin",
+			slug:              "kotlin",
+			shortName:         "kt",
+			extension:         ".kt",
+			lineComment:       "//",
+			blockCommentStart: "/*",
+			blockCommentEnd:   "*/",
+		},
 	}
 	mysqlGen = baseLang{
 		name:              "MySQL",
@@ -0,0 +1,337 @@
+package lang
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/leetcode"
+	"github.com/j178/leetgo/utils"
+)
+
+// LayoutMove moves a solution file, or the directory of a question, to its path in the current layout.
+type LayoutMove struct {
+	Question *leetcode.QuestionData
+	From     string
+	To       string
+	outDir   string
+}
+
+var (
+	headerURLPat = regexp.MustCompile(`https://leetcode\.(?:com|cn)/(contest/[\w-]+/)?problems/([\w-]+)/`)
+	leadingIDPat = regexp.MustCompile(`^0*(\d+)(?:\D|$)`)
+	// Directories that never contain solutions.
+	skippedDirs = map[string]bool{
+		"node_modules": true,
+		"target":       true,
+		"vendor":       true,
+		"build":        true,
+	}
+)
+
+// headerLines is the number of lines searched for the question URL, it's in the header block.
+const headerLines = 10
+
+// questionFromHeader finds the question by the URL in the header of a generated file,
+// contest reports whether the file is a contest solution.
+func questionFromHeader(path string, c leetcode.Client) (q *leetcode.QuestionData, contest bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	slug := ""
+	scanner := bufio.NewScanner(f)
+	for i := 0; i < headerLines && scanner.Scan(); i++ {
+		m := headerURLPat.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		if m[1] != "" {
+			contest = true
+		}
+		if slug == "" {
+			slug = m[2]
+		}
+	}
+	if slug == "" {
+		return nil, contest
+	}
+	q, err = leetcode.QuestionBySlug(slug, c)
+	if err != nil {
+		return nil, contest
+	}
+	return q, contest
+}
+
+// identifySolution finds the question of a solution file by, in order:
+//  1. the question URL in its header,
+//  2. the metadata file of its question directory,
+//  3. the slug or ID of a recently generated question in its path,
+//  4. the question ID that the file or directory name starts with.
+//
+// Contest solutions are not identified, they are kept in the contest out_dir.
+func identifySolution(path string, recent []config.LastQuestion, c leetcode.Client) (q *leetcode.QuestionData, contest bool) {
+	q, contest = questionFromHeader(path, c)
+	if contest || q != nil {
+		return q, contest
+	}
+
+	// Variants live in subdirectories of the question directory.
+	dir := filepath.Dir(path)
+	for _, d := range []string{dir, filepath.Dir(dir)} {
+		if meta := readQuestionMeta(d); meta != nil {
+			q, _ = leetcode.QuestionBySlug(meta.Slug, c)
+			if q != nil {
+				return q, false
+			}
+		}
+	}
+
+	tokens := make(map[string]bool)
+	for _, token := range pathTokenPat.FindAllString(strings.ToLower(path), -1) {
+		if isNumber(token) {
+			token = strings.TrimLeft(token, "0")
+		}
+		tokens[token] = true
+	}
+	for _, h := range recent {
+		if tokens[h.Slug] || tokens[h.FrontendID] {
+			q, _ = leetcode.QuestionBySlug(h.Slug, c)
+			if q != nil {
+				return q, false
+			}
+		}
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	m := leadingIDPat.FindStringSubmatch(name)
+	if m == nil {
+		m = leadingIDPat.FindStringSubmatch(filepath.Base(dir))
+	}
+	if m == nil {
+		return nil, false
+	}
+	q, _ = leetcode.QuestionFromCacheByID(m[1], c)
+	return q, false
+}
+
+// recentQuestions returns the questions recorded in the state, the last generated one first.
+func recentQuestions() []config.LastQuestion {
+	state := config.LoadState()
+	var recent []config.LastQuestion
+	for i := 0; ; i++ {
+		h, ok := state.RecentQuestion(i)
+		if !ok {
+			return recent
+		}
+		recent = append(recent, h)
+	}
+}
+
+// isGeneratedCode reports whether the file has the code markers of generated solutions.
+func isGeneratedCode(path string) bool {
+	content, err := os.ReadFile(path)
+	return err == nil && strings.Contains(string(content), config.CodeBeginMarker)
+}
+
+// PlanLayoutMigration scans the project for solutions of the current language, and returns the moves
+// that put them at the paths of the current filename_template and out_dir.
+// Languages that generate a directory per question move the whole directory, together with testcases.txt
+// and variants; other languages move the code file, its variants and the description file.
+// Generated code files whose question can't be identified are returned as unidentified, they are not moved.
+func PlanLayoutMigration(c leetcode.Client) (moves []LayoutMove, unidentified []string, err error) {
+	cfg := config.Get()
+	gen, err := GetGenerator(cfg.Code.Lang)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := cfg.ProjectRoot()
+	recent := recentQuestions()
+	ext := ""
+	if l, ok := gen.(interface{ codeExtension() string }); ok {
+		ext = l.codeExtension()
+	}
+
+	seenDirs := make(map[string]bool)
+	flatFiles := make(map[string][]string)
+	flatPaths := make(map[string]*GenerateResult)
+	err = filepath.WalkDir(
+		root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != root && (strings.HasPrefix(d.Name(), ".") || skippedDirs[d.Name()]) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			q, contest := identifySolution(path, recent, c)
+			if q == nil {
+				if !contest && filepath.Ext(path) == ext && isGeneratedCode(path) {
+					unidentified = append(unidentified, path)
+				}
+				return nil
+			}
+			paths, err := gen.GeneratePaths(q)
+			if err != nil {
+				log.Debug("failed to generate paths", "question", q.TitleSlug, "err", err)
+				return nil
+			}
+			paths.SetOutDir(getOutDir(q, gen))
+			codeFile := paths.GetFile(CodeFile)
+			if codeFile == nil || filepath.Ext(path) != filepath.Ext(codeFile.Filename) {
+				return nil
+			}
+
+			if paths.SubDir == "" {
+				flatFiles[q.TitleSlug] = append(flatFiles[q.TitleSlug], path)
+				flatPaths[q.TitleSlug] = paths
+				return nil
+			}
+			dir := filepath.Dir(path)
+			// Variants live in subdirectories of the question directory, they move with it.
+			if utils.IsExist(filepath.Join(filepath.Dir(dir), codeFile.Filename)) || seenDirs[dir] {
+				return nil
+			}
+			seenDirs[dir] = true
+			to := filepath.Join(paths.OutDir, paths.SubDir)
+			if dir != to {
+				moves = append(moves, LayoutMove{Question: q, From: dir, To: to, outDir: paths.OutDir})
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for slug, files := range flatFiles {
+		moves = append(moves, planFlatMoves(flatPaths[slug], files)...)
+	}
+	sort.Slice(
+		moves, func(i, j int) bool {
+			return moves[i].From < moves[j].From
+		},
+	)
+	return moves, unidentified, nil
+}
+
+// planFlatMoves moves the files of a question that are generated directly in the out dir.
+// The shortest file is the main solution, files named like "<main>.<variant><ext>" are its variants.
+func planFlatMoves(paths *GenerateResult, files []string) []LayoutMove {
+	sort.Slice(
+		files, func(i, j int) bool {
+			return len(files[i]) < len(files[j])
+		},
+	)
+	ext := filepath.Ext(files[0])
+	oldBase := strings.TrimSuffix(files[0], ext)
+	code := paths.GetFile(CodeFile)
+	newCode := code.GetPath()
+	newBase := strings.TrimSuffix(newCode, filepath.Ext(newCode))
+
+	var moves []LayoutMove
+	for _, f := range files {
+		suffix := strings.TrimPrefix(strings.TrimSuffix(f, ext), oldBase)
+		if suffix != "" && !strings.HasPrefix(suffix, ".") {
+			log.Warn("skip file of the same question", "file", utils.RelToCwd(f), "question", paths.Question.TitleSlug)
+			continue
+		}
+		to := newCode
+		if suffix != "" {
+			to = filepath.Join(paths.OutDir, paths.SubDir, variantFilename(paths, code.Filename, suffix[1:]))
+		}
+		if f != to {
+			moves = append(moves, LayoutMove{Question: paths.Question, From: f, To: to, outDir: paths.OutDir})
+		}
+	}
+	newDoc := newBase + ".md"
+	if doc := paths.GetFile(DocFile); doc != nil {
+		newDoc = doc.GetPath()
+	}
+	if doc := oldBase + ".md"; doc != newDoc && utils.IsExist(doc) {
+		moves = append(moves, LayoutMove{Question: paths.Question, From: doc, To: newDoc, outDir: paths.OutDir})
+	}
+	return moves
+}
+
+// MigrateLayout applies the moves, files are moved with `git mv` inside a git repository,
+// so that their history is kept. Moves whose target already exists are skipped.
+func MigrateLayout(moves []LayoutMove) error {
+	cfg := config.Get()
+	gen, err := GetGenerator(cfg.Code.Lang)
+	if err != nil {
+		return err
+	}
+	root := cfg.ProjectRoot()
+	inGit := exec.Command("git", "-C", root, "rev-parse", "--is-inside-work-tree").Run() == nil
+
+	outDirs := make(map[string]bool)
+	for _, m := range moves {
+		err := moveFile(m.From, m.To, inGit)
+		if err != nil {
+			log.Error("failed to move", "from", utils.RelToCwd(m.From), "to", utils.RelToCwd(m.To), "err", err)
+			continue
+		}
+		log.Info("moved", "from", utils.RelToCwd(m.From), "to", utils.RelToCwd(m.To))
+		removeEmptyDirs(filepath.Dir(m.From), root)
+		outDirs[m.outDir] = true
+	}
+
+	for outDir := range outDirs {
+		err = initializeWorkspace(gen, outDir, false)
+		if err != nil {
+			log.Error("initialize workspace failed", "lang", gen.Slug(), "err", err)
+		}
+		err = syncWorkspace(gen, outDir)
+		if err != nil {
+			log.Error("failed to update workspace files", "lang", gen.Slug(), "err", err)
+		}
+	}
+	return nil
+}
+
+func moveFile(from string, to string, inGit bool) error {
+	if utils.IsExist(to) {
+		return fmt.Errorf("%s already exists", utils.RelToCwd(to))
+	}
+	err := utils.CreateIfNotExists(filepath.Dir(to), true)
+	if err != nil {
+		return err
+	}
+	if inGit {
+		// git mv fails for untracked files, they are renamed directly.
+		output, err := exec.Command("git", "mv", from, to).CombinedOutput()
+		if err == nil {
+			return nil
+		}
+		log.Debug("git mv failed, rename instead", "output", strings.TrimSpace(string(output)))
+	}
+	return os.Rename(from, to)
+}
+
+// removeEmptyDirs removes dir and its parents if they are empty, up to root.
+func removeEmptyDirs(dir string, root string) {
+	for dir != root && strings.HasPrefix(dir, root) {
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) > 0 {
+			return
+		}
+		if os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
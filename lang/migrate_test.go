@@ -0,0 +1,53 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/j178/leetgo/leetcode"
+)
+
+func TestPlanFlatMoves(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, "old", "0001.two-sum")
+	for _, f := range []string{old + ".cpp", old + ".hash.cpp", old + ".md"} {
+		err := os.MkdirAll(filepath.Dir(f), 0o755)
+		if err == nil {
+			err = os.WriteFile(f, nil, 0o644)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	paths := &GenerateResult{Question: &leetcode.QuestionData{TitleSlug: "two-sum"}, OutDir: filepath.Join(dir, "cpp")}
+	paths.AddFile(FileOutput{Filename: filepath.Join("easy", "two-sum.cpp"), Type: CodeFile})
+	moves := planFlatMoves(paths, []string{old + ".hash.cpp", old + ".cpp"})
+
+	newBase := filepath.Join(dir, "cpp", "easy", "two-sum")
+	want := map[string]string{
+		old + ".cpp":      newBase + ".cpp",
+		old + ".hash.cpp": newBase + ".hash.cpp",
+		old + ".md":       newBase + ".md",
+	}
+	if len(moves) != len(want) {
+		t.Fatalf("got %d moves, want %d: %v", len(moves), len(want), moves)
+	}
+	for _, m := range moves {
+		if want[m.From] != m.To {
+			t.Errorf("%s moved to %s, want %s", m.From, m.To, want[m.From])
+		}
+	}
+}
+
+func TestHeaderURLPattern(t *testing.T) {
+	m := headerURLPat.FindStringSubmatch("// https://leetcode.cn/problems/two-sum/")
+	if m == nil || m[1] != "" || m[2] != "two-sum" {
+		t.Errorf("unexpected match: %v", m)
+	}
+	m = headerURLPat.FindStringSubmatch("# https://leetcode.com/contest/weekly-contest-300/problems/decode-the-message/")
+	if m == nil || m[1] == "" || m[2] != "decode-the-message" {
+		t.Errorf("unexpected match: %v", m)
+	}
+}
@@ -3,3 +3,55 @@ package lang
 type python struct {
 	baseLang
 }
+
+const pythonPyproject = `[project]
+name = "leetcode-solutions"
+version = "0.1.0"
+requires-python = ">=3.8"
+
+[tool.pyright]
+typeCheckingMode = "basic"
+reportRedeclaration = false
+
+[tool.mypy]
+ignore_missing_imports = true
+`
+
+// pythonBuiltins declares what LeetCode provides implicitly, type checkers like pyright
+// treat names in __builtins__.pyi as builtins.
+const pythonBuiltins = `# Code generated by leetgo. DO NOT EDIT.
+# Names declared here are treated as builtins, just like they are on LeetCode.
+from typing import *
+from collections import *
+from functools import *
+from heapq import *
+from itertools import *
+from math import *
+from bisect import *
+
+class ListNode:
+    val: int
+    next: Optional[ListNode]
+    def __init__(self, val: int = 0, next: Optional[ListNode] = None) -> None: ...
+
+class TreeNode:
+    val: int
+    left: Optional[TreeNode]
+    right: Optional[TreeNode]
+    def __init__(self, val: int = 0, left: Optional[TreeNode] = None, right: Optional[TreeNode] = None) -> None: ...
+`
+
+func (p python) scaffoldFiles() []scaffoldFile {
+	return []scaffoldFile{
+		{Path: "pyproject.toml", Content: pythonPyproject},
+		{Path: "__builtins__.pyi", Content: pythonBuiltins, Managed: true},
+	}
+}
+
+func (p python) HasInitialized(outDir string) (bool, error) {
+	return hasScaffold(outDir, p.scaffoldFiles())
+}
+
+func (p python) Initialize(outDir string) error {
+	return writeScaffold(outDir, p.scaffoldFiles())
+}
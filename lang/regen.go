@@ -0,0 +1,117 @@
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/leetcode"
+	"github.com/j178/leetgo/utils"
+)
+
+// ErrNotGenerated is returned when regenerating a question that has no solution file yet.
+var ErrNotGenerated = errors.New("solution file not found, use `leetgo pick` to generate it first")
+
+// RegenFile is a file whose content changes after regeneration.
+type RegenFile struct {
+	Path       string
+	Type       FileType
+	OldContent string
+	NewContent string
+	images     []imageAsset
+}
+
+// Write writes the regenerated content to the file.
+func (f RegenFile) Write() error {
+	err := utils.CreateIfNotExists(f.Path, false)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(f.Path, utils.StringToBytes(f.NewContent), 0o644)
+	if err != nil {
+		return err
+	}
+	log.Info("regenerated", "file", utils.RelToCwd(f.Path))
+	downloadImages(f.images)
+	return nil
+}
+
+// Regenerate renders the files of the question again with current templates, blocks and modifiers,
+// and keeps the code between CodeBeginMarker and CodeEndMarker as it is.
+// Nothing is written, only files that would change are returned.
+// testcases.txt is left untouched unless withTestCases is true.
+func Regenerate(q *leetcode.QuestionData, withTestCases bool) ([]RegenFile, error) {
+	cfg := config.Get()
+	gen, err := GetGenerator(cfg.Code.Lang)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := GeneratePathsOnly(q)
+	if err != nil {
+		return nil, err
+	}
+	codeFile := paths.GetFile(CodeFile)
+	if codeFile == nil {
+		return nil, fmt.Errorf("no code file generated")
+	}
+	if !utils.IsExist(codeFile.GetPath()) {
+		return nil, ErrNotGenerated
+	}
+
+	err = q.Fulfill()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question data: %w", err)
+	}
+	code, err := GetSolutionCode(q)
+	if err != nil {
+		return nil, err
+	}
+	err = ensureCodeSnippet(gen, q)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := gen.Generate(q)
+	if err != nil {
+		return nil, err
+	}
+	applyVariant(result, currentVariant())
+	result.SetOutDir(getOutDir(q, gen))
+	images := localizeImages(result)
+
+	var files []RegenFile
+	for _, f := range result.Files {
+		if f.Type&TestCasesFile != 0 && !withTestCases {
+			continue
+		}
+		content := f.Content
+		if f.Type&CodeFile != 0 {
+			content = replaceCode(content, code)
+		}
+		path := f.GetPath()
+		var old string
+		if utils.IsExist(path) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			old = string(data)
+		}
+		if old == content {
+			continue
+		}
+		files = append(
+			files, RegenFile{
+				Path:       path,
+				Type:       f.Type,
+				OldContent: old,
+				NewContent: content,
+				images:     images[path],
+			},
+		)
+	}
+	return files, nil
+}
@@ -0,0 +1,118 @@
+package lang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/leetcode"
+)
+
+type rust struct {
+	baseLang
+}
+
+const rustCargoToml = `[package]
+name = "leetcode-solutions"
+version = "0.1.0"
+edition = "2021"
+
+[lib]
+path = "lib.rs"
+`
+
+const rustLibHeader = `// Code generated by leetgo. DO NOT EDIT.
+// Every solution is a module of this crate, so rust-analyzer can check them.
+#![allow(dead_code, unused_imports)]
+
+pub use std::cell::RefCell;
+pub use std::collections::*;
+pub use std::rc::Rc;
+
+#[derive(PartialEq, Eq, Clone, Debug)]
+pub struct ListNode {
+    pub val: i32,
+    pub next: Option<Box<ListNode>>,
+}
+
+impl ListNode {
+    #[inline]
+    pub fn new(val: i32) -> Self {
+        ListNode { next: None, val }
+    }
+}
+
+#[derive(Debug, PartialEq, Eq)]
+pub struct TreeNode {
+    pub val: i32,
+    pub left: Option<Rc<RefCell<TreeNode>>>,
+    pub right: Option<Rc<RefCell<TreeNode>>>,
+}
+
+impl TreeNode {
+    #[inline]
+    pub fn new(val: i32) -> Self {
+        TreeNode {
+            val,
+            left: None,
+            right: None,
+        }
+    }
+}
+`
+
+func (r rust) Generate(q *leetcode.QuestionData) (*GenerateResult, error) {
+	// Bring the common data structures into scope, they are defined in lib.rs.
+	// Every solution declares its own Solution, so that methods of the same name don't conflict.
+	result, err := r.generateFiles(
+		q, config.Block{
+			Name:     internalBeforeMarker,
+			Template: "use crate::*;\n\nstruct Solution;\n",
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result.Lang = r
+	return result, nil
+}
+
+func (r rust) HasInitialized(outDir string) (bool, error) {
+	return hasScaffold(
+		outDir, []scaffoldFile{
+			{Path: "Cargo.toml"},
+			{Path: "lib.rs"},
+		},
+	)
+}
+
+func (r rust) Initialize(outDir string) error {
+	err := writeScaffold(
+		outDir, []scaffoldFile{
+			{Path: "Cargo.toml", Content: rustCargoToml},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return r.syncWorkspace(outDir)
+}
+
+// syncWorkspace registers every solution file as a module in lib.rs.
+func (r rust) syncWorkspace(outDir string) error {
+	files, err := listSourceFiles(outDir, r.extension, "lib.rs", "build.rs")
+	if err != nil {
+		return err
+	}
+	var sb strings.Builder
+	sb.WriteString(rustLibHeader)
+	if len(files) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, f := range files {
+		sb.WriteString(fmt.Sprintf("#[path = %q]\nmod %s;\n", f, toIdentifier(strings.TrimSuffix(f, r.extension))))
+	}
+	return os.WriteFile(filepath.Join(outDir, "lib.rs"), []byte(sb.String()), 0o644)
+}
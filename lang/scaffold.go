@@ -0,0 +1,150 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/j178/leetgo/utils"
+)
+
+// scaffoldFile is a project file that makes IDEs understand the generated code.
+// Managed files are owned by leetgo and rewritten on every initialization,
+// others are only created when missing, so user edits are kept.
+type scaffoldFile struct {
+	Path    string
+	Content string
+	Managed bool
+}
+
+// workspaceSyncer is implemented by languages whose project files must list every generated file.
+type workspaceSyncer interface {
+	syncWorkspace(dir string) error
+}
+
+func hasScaffold(dir string, files []scaffoldFile) (bool, error) {
+	for _, f := range files {
+		_, err := os.Stat(filepath.Join(dir, f.Path))
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func writeScaffold(dir string, files []scaffoldFile) error {
+	for _, f := range files {
+		path := filepath.Join(dir, f.Path)
+		if !f.Managed && utils.IsExist(path) {
+			continue
+		}
+		err := utils.CreateIfNotExists(filepath.Dir(path), true)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(path, []byte(f.Content), 0o644)
+		if err != nil {
+			return err
+		}
+		log.Debug("scaffold file written", "file", path)
+	}
+	return nil
+}
+
+// listSourceFiles returns the generated files with the extension directly under dir, sorted by name.
+func listSourceFiles(dir string, ext string, exclude ...string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ext {
+			continue
+		}
+		skip := false
+		for _, ex := range exclude {
+			if e.Name() == ex {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			files = append(files, e.Name())
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+var nonIdentChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// toIdentifier converts a generated filename to a valid module or package name, e.g. "0001.two-sum" to "q0001_two_sum".
+func toIdentifier(name string) string {
+	name = nonIdentChars.ReplaceAllString(strings.ToLower(name), "_")
+	name = strings.Trim(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "q" + name
+	}
+	return name
+}
+
+// initializeWorkspace initializes the workspace of the language if it hasn't been initialized.
+// A failed check is only logged, initialization is skipped then.
+func initializeWorkspace(gen Lang, outDir string, force bool) error {
+	t, ok := gen.(NeedInitialization)
+	if !ok {
+		return nil
+	}
+	if !force {
+		ok, err := t.HasInitialized(outDir)
+		if err != nil {
+			log.Error("check initialization failed, skip initialization", "lang", gen.Slug(), "err", err)
+			return nil
+		}
+		if ok {
+			return nil
+		}
+	}
+	log.Info("initializing workspace", "lang", gen.Slug(), "dir", outDir)
+	return t.Initialize(outDir)
+}
+
+func syncWorkspace(gen Lang, outDir string) error {
+	if s, ok := gen.(workspaceSyncer); ok {
+		return s.syncWorkspace(outDir)
+	}
+	return nil
+}
+
+// InitWorkspace creates the project scaffold of the language under projectRoot,
+// so that IDEs can resolve the generated code. If force is true, initialization
+// is run even if the workspace has already been initialized.
+func InitWorkspace(gen Lang, projectRoot string, force bool) (string, error) {
+	outDir := langOutDir(gen, projectRoot)
+	err := utils.CreateIfNotExists(outDir, true)
+	if err != nil {
+		return outDir, err
+	}
+	err = initializeWorkspace(gen, outDir, force)
+	if err != nil {
+		return outDir, err
+	}
+	return outDir, syncWorkspace(gen, outDir)
+}
+
+// HasInitialized reports whether the workspace of the language is ready.
+// Languages that don't need initialization are always ready.
+func HasInitialized(gen Lang, outDir string) (bool, error) {
+	if t, ok := gen.(NeedInitialization); ok {
+		return t.HasInitialized(outDir)
+	}
+	return true, nil
+}
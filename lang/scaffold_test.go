@@ -0,0 +1,18 @@
+package lang
+
+import "testing"
+
+func TestToIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"0001.two-sum":         "q0001_two_sum",
+		"two-sum":              "two_sum",
+		"Two Sum II":           "two_sum_ii",
+		"--":                   "q",
+		"LCP 01.guess-numbers": "lcp_01_guess_numbers",
+	}
+	for name, want := range tests {
+		if got := toIdentifier(name); got != want {
+			t.Errorf("toIdentifier(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
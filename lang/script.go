@@ -0,0 +1,289 @@
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/log"
+	"github.com/dop251/goja"
+	"github.com/goccy/go-json"
+
+	"github.com/j178/leetgo/leetcode"
+)
+
+// scriptPrelude is loaded into the VM before modifier scripts, it provides helpers to edit code.
+const scriptPrelude = `
+function toRegExp(pattern, flags) {
+  if (pattern instanceof RegExp) {
+    return pattern;
+  }
+  return new RegExp(pattern, flags);
+}
+
+function globalRegExp(pattern) {
+  var re = toRegExp(pattern);
+  return re.global ? re : new RegExp(re.source, re.flags + "g");
+}
+
+// test reports whether code matches pattern.
+function test(code, pattern) {
+  return toRegExp(pattern).test(code);
+}
+
+// matchAll returns all matches of pattern, each match is an array of the groups.
+function matchAll(code, pattern) {
+  var re = globalRegExp(pattern);
+  var result = [];
+  var m;
+  while ((m = re.exec(code)) !== null) {
+    result.push(Array.prototype.slice.call(m));
+    if (m[0] === "") {
+      re.lastIndex++;
+    }
+  }
+  return result;
+}
+
+// replaceAll replaces all matches of pattern with replacement, which can be a string or a function.
+function replaceAll(code, pattern, replacement) {
+  return code.replace(globalRegExp(pattern), replacement);
+}
+
+function splitLines(code) {
+  return code.split("\n");
+}
+
+function joinLines(lines) {
+  return lines.join("\n");
+}
+
+// mapLines calls fn(line, index) for each line, returning null or undefined removes the line.
+function mapLines(code, fn) {
+  var result = [];
+  splitLines(code).forEach(function (line, i) {
+    var r = fn(line, i);
+    if (r !== null && r !== undefined) {
+      result.push(r);
+    }
+  });
+  return joinLines(result);
+}
+
+// removeLines removes lines matching pattern.
+function removeLines(code, pattern) {
+  var re = toRegExp(pattern);
+  return mapLines(code, function (line) {
+    return re.test(line) ? null : line;
+  });
+}
+
+// insertBefore inserts text before every line matching pattern.
+function insertBefore(code, pattern, text) {
+  var re = toRegExp(pattern);
+  return mapLines(code, function (line) {
+    return re.test(line) ? text + "\n" + line : line;
+  });
+}
+
+// insertAfter inserts text after every line matching pattern.
+function insertAfter(code, pattern, text) {
+  var re = toRegExp(pattern);
+  return mapLines(code, function (line) {
+    return re.test(line) ? line + "\n" + text : line;
+  });
+}
+
+// indentOf returns the leading whitespace of line.
+function indentOf(line) {
+  return line.match(/^\s*/)[0];
+}
+
+function __deepFreeze(obj) {
+  Object.getOwnPropertyNames(obj).forEach(function (name) {
+    var v = obj[name];
+    if (v && typeof v === "object") {
+      __deepFreeze(v);
+    }
+  });
+  return Object.freeze(obj);
+}
+
+function __question(data) {
+  return __deepFreeze(JSON.parse(data));
+}
+`
+
+var preludeProgram = goja.MustCompile("prelude.js", scriptPrelude, false)
+
+type scriptParam struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type scriptMethod struct {
+	Name       string        `json:"name"`
+	Params     []scriptParam `json:"params"`
+	ReturnType string        `json:"returnType"`
+}
+
+type scriptMetaData struct {
+	Name              string         `json:"name"`
+	Params            []scriptParam  `json:"params"`
+	ReturnType        string         `json:"returnType"`
+	SystemDesign      bool           `json:"systemDesign"`
+	ClassName         string         `json:"className"`
+	ConstructorParams []scriptParam  `json:"constructorParams"`
+	Methods           []scriptMethod `json:"methods"`
+}
+
+// scriptQuestion is the question object passed to modify(code, question).
+type scriptQuestion struct {
+	Slug       string         `json:"slug"`
+	Id         string         `json:"id"`
+	QuestionId string         `json:"questionId"`
+	Title      string         `json:"title"`
+	Difficulty string         `json:"difficulty"`
+	Tags       []string       `json:"tags"`
+	Content    string         `json:"content"`
+	Lang       string         `json:"lang"`
+	IsContest  bool           `json:"isContest"`
+	MetaData   scriptMetaData `json:"metaData"`
+}
+
+func toScriptParams(params []leetcode.MetaDataParam) []scriptParam {
+	ans := make([]scriptParam, 0, len(params))
+	for _, p := range params {
+		ans = append(ans, scriptParam{Name: p.Name, Type: p.Type})
+	}
+	return ans
+}
+
+func newScriptQuestion(q *leetcode.QuestionData, lang Lang) scriptQuestion {
+	m := q.MetaData
+	meta := scriptMetaData{
+		Name:              m.Name,
+		Params:            toScriptParams(m.Params),
+		SystemDesign:      m.SystemDesign,
+		ClassName:         m.ClassName,
+		ConstructorParams: toScriptParams(m.Constructor.Params),
+		Methods:           make([]scriptMethod, 0, len(m.Methods)),
+	}
+	if m.Return != nil {
+		meta.ReturnType = m.Return.Type
+	}
+	for _, method := range m.Methods {
+		meta.Methods = append(
+			meta.Methods, scriptMethod{
+				Name:       method.Name,
+				Params:     toScriptParams(method.Params),
+				ReturnType: method.Return.Type,
+			},
+		)
+	}
+	content, _ := q.GetContent()
+	return scriptQuestion{
+		Slug:       q.TitleSlug,
+		Id:         q.QuestionFrontendId,
+		QuestionId: q.QuestionId,
+		Title:      q.GetTitle(),
+		Difficulty: q.Difficulty,
+		Tags:       q.TagSlugs(),
+		Content:    content,
+		Lang:       lang.Slug(),
+		IsContest:  q.IsContest(),
+		MetaData:   meta,
+	}
+}
+
+// scriptError formats JavaScript exceptions with the script name and line number.
+func scriptError(name string, err error) error {
+	var ex *goja.Exception
+	if errors.As(err, &ex) {
+		// Find the innermost frame in the script itself, the stack may start in the prelude.
+		pos := regexp.MustCompile(regexp.QuoteMeta(name) + `:(\d+):(\d+)`).FindStringSubmatch(ex.String())
+		if pos != nil {
+			return fmt.Errorf("%s:%s:%s: %s", name, pos[1], pos[2], ex.Value())
+		}
+		return fmt.Errorf("%s: %s", name, ex.Value())
+	}
+	return fmt.Errorf("%s: %w", name, err)
+}
+
+// setupScriptLogger adds the log function to vm: log(...), log.debug(...), log.warn(...) and log.error(...).
+func setupScriptLogger(vm *goja.Runtime, name string) error {
+	logger := log.With("script", name)
+	logFn := func(fn func(msg any, keyvals ...any)) func(goja.FunctionCall) goja.Value {
+		return func(call goja.FunctionCall) goja.Value {
+			args := make([]string, 0, len(call.Arguments))
+			for _, a := range call.Arguments {
+				args = append(args, a.String())
+			}
+			fn(strings.Join(args, " "))
+			return goja.Undefined()
+		}
+	}
+	obj := vm.ToValue(logFn(logger.Info)).ToObject(vm)
+	levels := map[string]func(msg any, keyvals ...any){
+		"debug": logger.Debug,
+		"info":  logger.Info,
+		"warn":  logger.Warn,
+		"error": logger.Error,
+	}
+	for k, fn := range levels {
+		err := obj.Set(k, logFn(fn))
+		if err != nil {
+			return err
+		}
+	}
+	return vm.Set("log", obj)
+}
+
+// compileModifierScript runs the script and returns its modify(code, question) function.
+func compileModifierScript(name string, script string, lang Lang) (ModifierFunc, error) {
+	program, err := goja.Compile(name, script, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile script: %w", err)
+	}
+	vm := goja.New()
+	_, err = vm.RunProgram(preludeProgram)
+	if err != nil {
+		return nil, err
+	}
+	err = setupScriptLogger(vm, name)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vm.RunProgram(program)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run script: %w", scriptError(name, err))
+	}
+	modify, ok := goja.AssertFunction(vm.Get("modify"))
+	if !ok {
+		return nil, fmt.Errorf("failed to get modify function from script %s", name)
+	}
+	newQuestion, _ := goja.AssertFunction(vm.Get("__question"))
+
+	// goja runtime is not goroutine safe.
+	var mu sync.Mutex
+	return func(code string, q *leetcode.QuestionData) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		data, err := json.Marshal(newScriptQuestion(q, lang))
+		if err != nil {
+			return "", err
+		}
+		question, err := newQuestion(goja.Undefined(), vm.ToValue(string(data)))
+		if err != nil {
+			return "", err
+		}
+		result, err := modify(goja.Undefined(), vm.ToValue(code), question)
+		if err != nil {
+			return "", fmt.Errorf("modifier script failed: %w", scriptError(name, err))
+		}
+		return result.String(), nil
+	}, nil
+}
@@ -0,0 +1,68 @@
+package lang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/j178/leetgo/leetcode"
+)
+
+func TestModifierScript(t *testing.T) {
+	script := `
+function modify(code, question) {
+  log.debug("modifying", question.slug);
+  code = removeLines(code, /^\s*\/\//);
+  code = replaceAll(code, "func ", "func /* " + question.metaData.returnType + " */ ");
+  return "// " + question.id + " " + question.metaData.params.map(function (p) { return p.name; }).join(",") + "\n" + code;
+}
+`
+	modify, err := compileModifierScript("test.js", script, golangGen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := &leetcode.QuestionData{
+		TitleSlug:          "two-sum",
+		QuestionFrontendId: "1",
+		MetaData: leetcode.MetaData{
+			Name:   "twoSum",
+			Params: []leetcode.MetaDataParam{{Name: "nums", Type: "integer[]"}, {Name: "target", Type: "integer"}},
+			Return: &leetcode.MetaDataReturn{Type: "integer[]"},
+		},
+	}
+	got, err := modify("// comment\nfunc twoSum(nums []int, target int) []int {\n}", q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "// 1 nums,target\nfunc /* integer[] */ twoSum(nums []int, target int) []int {\n}"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestModifierScriptError(t *testing.T) {
+	_, err := compileModifierScript("bad.js", "function modify(code) {\n  return code +;\n}", golangGen)
+	if err == nil || !strings.Contains(err.Error(), "bad.js") {
+		t.Errorf("expected syntax error with script name, got %v", err)
+	}
+
+	_, err = compileModifierScript("runtime.js", "\n\nnotDefined();\nfunction modify(code) { return code; }", golangGen)
+	if err == nil || !strings.Contains(err.Error(), "runtime.js:3:") {
+		t.Errorf("expected runtime error with line number, got %v", err)
+	}
+
+	script := `'use strict';
+function modify(code, question) {
+  question.slug = "changed";
+  return code;
+}
+`
+	modify, err := compileModifierScript("readonly.js", script, golangGen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Question is read-only.
+	_, err = modify("code", &leetcode.QuestionData{TitleSlug: "two-sum"})
+	if err == nil || !strings.Contains(err.Error(), "readonly.js:3:") {
+		t.Errorf("expected error with line number, got %v", err)
+	}
+}
@@ -0,0 +1,664 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/viper"
+
+	"github.com/j178/leetgo/leetcode"
+	"github.com/j178/leetgo/utils"
+)
+
+// Some questions have no code snippet for a language, e.g. contest questions parsed from HTML,
+// or languages that LeetCode has not published yet. A skeleton can be built from the metadata instead.
+
+// synthesizer builds the code skeleton of a language from the question metadata.
+type synthesizer func(m *leetcode.MetaData) string
+
+var synthesizers = map[string]synthesizer{
+	"golang":     synthesizeGo,
+	"python3":    synthesizePython,
+	"cpp":        synthesizeCpp,
+	"java":       synthesizeJava,
+	"rust":       synthesizeRust,
+	"javascript": synthesizeJavaScript,
+	"typescript": synthesizeTypeScript,
+	"kotlin":     synthesizeKotlin,
+	"csharp":     synthesizeCSharp,
+	"swift":      synthesizeSwift,
+	"php":        synthesizePHP,
+	"ruby":       synthesizeRuby,
+}
+
+// synthesizeRequested reports whether --synthesize is set.
+func synthesizeRequested() bool {
+	return viper.GetBool("synthesize")
+}
+
+// SynthesizeSnippet builds the code skeleton of the question for the language from its metadata.
+func SynthesizeSnippet(l Lang, q *leetcode.QuestionData) (string, error) {
+	fn, ok := synthesizers[l.Slug()]
+	if !ok {
+		return "", fmt.Errorf("synthesizing code is not supported for %s", l.Name())
+	}
+	m := &q.MetaData
+	if m.Name == "" && !m.SystemDesign {
+		return "", fmt.Errorf("question %s has no metadata to synthesize code from", q.TitleSlug)
+	}
+	return fn(m), nil
+}
+
+// ensureCodeSnippet synthesizes the code snippet if LeetCode doesn't provide one, or if --synthesize is set.
+func ensureCodeSnippet(l Lang, q *leetcode.QuestionData) error {
+	if q.GetCodeSnippet(l.Slug()) != "" && !synthesizeRequested() {
+		return nil
+	}
+	code, err := SynthesizeSnippet(l, q)
+	if err != nil {
+		if q.GetCodeSnippet(l.Slug()) != "" {
+			return err
+		}
+		return fmt.Errorf(`question "%s" doesn't support using "%s": %w`, q.TitleSlug, l.Slug(), err)
+	}
+	log.Info("code synthesized from question metadata", "question", q.TitleSlug, "lang", l.Slug())
+	q.SetCodeSnippet(l.Slug(), code)
+	return nil
+}
+
+func returnTypeOf(ret *leetcode.MetaDataReturn) string {
+	if ret == nil || ret.Type == "" {
+		return "void"
+	}
+	return ret.Type
+}
+
+func joinParams(params []leetcode.MetaDataParam, f func(p leetcode.MetaDataParam) string) string {
+	parts := make([]string, 0, len(params))
+	for _, p := range params {
+		parts = append(parts, f(p))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// elemType returns the element type of an array type like "integer[]".
+// Lists like "list<integer>" are arrays too, the same as they are normalized in question metadata.
+func elemType(t string) (string, bool) {
+	if strings.HasSuffix(t, "[]") {
+		return t[:len(t)-2], true
+	}
+	if strings.HasPrefix(t, "list<") && strings.HasSuffix(t, ">") {
+		return t[len("list<") : len(t)-1], true
+	}
+	return t, false
+}
+
+func synthesizeGo(m *leetcode.MetaData) string {
+	params := func(params []leetcode.MetaDataParam) string {
+		return joinParams(
+			params, func(p leetcode.MetaDataParam) string {
+				return p.Name + " " + convertToGoType(p.Type)
+			},
+		)
+	}
+	result := func(t string) string {
+		if t := convertToGoType(t); t != "" {
+			return " " + t
+		}
+		return ""
+	}
+	if !m.SystemDesign {
+		return fmt.Sprintf("func %s(%s)%s {\n    \n}", m.Name, params(m.Params), result(returnTypeOf(m.Return)))
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "type %s struct {\n    \n}\n\n\n", m.ClassName)
+	fmt.Fprintf(&sb, "func Constructor(%s) %s {\n    \n}\n", params(m.Constructor.Params), m.ClassName)
+	for _, method := range m.Methods {
+		fmt.Fprintf(
+			&sb, "\n\nfunc (this *%s) %s(%s)%s {\n    \n}\n",
+			m.ClassName, toGoFuncName(method.Name), params(method.Params), result(method.Return.Type),
+		)
+	}
+	return sb.String()
+}
+
+func pythonType(t string) string {
+	if e, ok := elemType(t); ok {
+		return "List[" + pythonType(e) + "]"
+	}
+	switch t {
+	case "integer", "long":
+		return "int"
+	case "double":
+		return "float"
+	case "boolean":
+		return "bool"
+	case "character", "string":
+		return "str"
+	case "void":
+		return "None"
+	case "TreeNode", "ListNode":
+		return "Optional[" + t + "]"
+	}
+	return t
+}
+
+func synthesizePython(m *leetcode.MetaData) string {
+	params := func(params []leetcode.MetaDataParam) string {
+		s := "self"
+		for _, p := range params {
+			s += ", " + p.Name + ": " + pythonType(p.Type)
+		}
+		return s
+	}
+	if !m.SystemDesign {
+		return fmt.Sprintf(
+			"class Solution:\n    def %s(%s) -> %s:\n        ",
+			m.Name, params(m.Params), pythonType(returnTypeOf(m.Return)),
+		)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "class %s:\n\n    def __init__(%s):\n        \n", m.ClassName, params(m.Constructor.Params))
+	for _, method := range m.Methods {
+		fmt.Fprintf(
+			&sb, "\n    def %s(%s) -> %s:\n        \n",
+			method.Name, params(method.Params), pythonType(method.Return.Type),
+		)
+	}
+	return sb.String()
+}
+
+func cppType(t string) string {
+	if e, ok := elemType(t); ok {
+		return "vector<" + cppType(e) + ">"
+	}
+	switch t {
+	case "integer":
+		return "int"
+	case "long":
+		return "long long"
+	case "boolean":
+		return "bool"
+	case "character":
+		return "char"
+	case "TreeNode", "ListNode":
+		return t + "*"
+	}
+	return t
+}
+
+func cppParams(params []leetcode.MetaDataParam) string {
+	return joinParams(
+		params, func(p leetcode.MetaDataParam) string {
+			t := cppType(p.Type)
+			if strings.HasPrefix(t, "vector<") {
+				t += "&"
+			}
+			return t + " " + p.Name
+		},
+	)
+}
+
+func synthesizeCpp(m *leetcode.MetaData) string {
+	if !m.SystemDesign {
+		return fmt.Sprintf(
+			"class Solution {\npublic:\n    %s %s(%s) {\n        \n    }\n};",
+			cppType(returnTypeOf(m.Return)), m.Name, cppParams(m.Params),
+		)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "class %s {\npublic:\n    %s(%s) {\n        \n    }\n", m.ClassName, m.ClassName, cppParams(m.Constructor.Params))
+	for _, method := range m.Methods {
+		fmt.Fprintf(
+			&sb, "    \n    %s %s(%s) {\n        \n    }\n",
+			cppType(method.Return.Type), method.Name, cppParams(method.Params),
+		)
+	}
+	sb.WriteString("};")
+	return sb.String()
+}
+
+func javaType(t string) string {
+	if e, ok := elemType(t); ok {
+		return javaType(e) + "[]"
+	}
+	switch t {
+	case "integer":
+		return "int"
+	case "character":
+		return "char"
+	case "string":
+		return "String"
+	}
+	return t
+}
+
+func javaParams(params []leetcode.MetaDataParam) string {
+	return joinParams(
+		params, func(p leetcode.MetaDataParam) string {
+			return javaType(p.Type) + " " + p.Name
+		},
+	)
+}
+
+func synthesizeJava(m *leetcode.MetaData) string {
+	if !m.SystemDesign {
+		return fmt.Sprintf(
+			"class Solution {\n    public %s %s(%s) {\n        \n    }\n}",
+			javaType(returnTypeOf(m.Return)), m.Name, javaParams(m.Params),
+		)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "class %s {\n\n    public %s(%s) {\n        \n    }\n", m.ClassName, m.ClassName, javaParams(m.Constructor.Params))
+	for _, method := range m.Methods {
+		fmt.Fprintf(
+			&sb, "    \n    public %s %s(%s) {\n        \n    }\n",
+			javaType(method.Return.Type), method.Name, javaParams(method.Params),
+		)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func rustType(t string) string {
+	if e, ok := elemType(t); ok {
+		return "Vec<" + rustType(e) + ">"
+	}
+	switch t {
+	case "integer":
+		return "i32"
+	case "long":
+		return "i64"
+	case "double":
+		return "f64"
+	case "boolean":
+		return "bool"
+	case "character":
+		return "char"
+	case "string":
+		return "String"
+	case "TreeNode":
+		return "Option<Rc<RefCell<TreeNode>>>"
+	case "ListNode":
+		return "Option<Box<ListNode>>"
+	}
+	return t
+}
+
+func rustParams(receiver string, params []leetcode.MetaDataParam) string {
+	parts := make([]string, 0, len(params)+1)
+	if receiver != "" {
+		parts = append(parts, receiver)
+	}
+	for _, p := range params {
+		parts = append(parts, utils.SnakeCase(p.Name)+": "+rustType(p.Type))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func rustSignature(name string, receiver string, params []leetcode.MetaDataParam, ret string) string {
+	s := fmt.Sprintf("fn %s(%s)", utils.SnakeCase(name), rustParams(receiver, params))
+	if ret != "void" {
+		s += " -> " + rustType(ret)
+	}
+	return s
+}
+
+func synthesizeRust(m *leetcode.MetaData) string {
+	if !m.SystemDesign {
+		return fmt.Sprintf(
+			"impl Solution {\n    pub %s {\n        \n    }\n}",
+			rustSignature(m.Name, "", m.Params, returnTypeOf(m.Return)),
+		)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "struct %s {\n\n}\n\n\nimpl %s {\n\n", m.ClassName, m.ClassName)
+	fmt.Fprintf(&sb, "    fn new(%s) -> Self {\n        \n    }\n", rustParams("", m.Constructor.Params))
+	for _, method := range m.Methods {
+		receiver := "&self"
+		if method.Return.Type == "void" {
+			receiver = "&mut self"
+		}
+		fmt.Fprintf(&sb, "    \n    %s {\n        \n    }\n", rustSignature(method.Name, receiver, method.Params, method.Return.Type))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func jsType(t string) string {
+	if e, ok := elemType(t); ok {
+		return jsType(e) + "[]"
+	}
+	switch t {
+	case "integer", "long", "double":
+		return "number"
+	case "character":
+		return "string"
+	}
+	return t
+}
+
+func jsDoc(indent string, params []leetcode.MetaDataParam, ret string) string {
+	var sb strings.Builder
+	sb.WriteString(indent + "/**\n")
+	for _, p := range params {
+		fmt.Fprintf(&sb, "%s * @param {%s} %s\n", indent, jsType(p.Type), p.Name)
+	}
+	if ret != "" {
+		fmt.Fprintf(&sb, "%s * @return {%s}\n", indent, jsType(ret))
+	}
+	sb.WriteString(indent + " */\n")
+	return sb.String()
+}
+
+func paramNamesOf(params []leetcode.MetaDataParam) string {
+	return joinParams(
+		params, func(p leetcode.MetaDataParam) string {
+			return p.Name
+		},
+	)
+}
+
+func synthesizeJavaScript(m *leetcode.MetaData) string {
+	if !m.SystemDesign {
+		ret := returnTypeOf(m.Return)
+		return jsDoc("", m.Params, ret) + fmt.Sprintf("var %s = function(%s) {\n    \n};", m.Name, paramNamesOf(m.Params))
+	}
+	var sb strings.Builder
+	sb.WriteString(jsDoc("", m.Constructor.Params, ""))
+	fmt.Fprintf(&sb, "var %s = function(%s) {\n    \n};\n", m.ClassName, paramNamesOf(m.Constructor.Params))
+	for _, method := range m.Methods {
+		sb.WriteString("\n" + jsDoc("", method.Params, method.Return.Type))
+		fmt.Fprintf(
+			&sb, "%s.prototype.%s = function(%s) {\n    \n};\n",
+			m.ClassName, method.Name, paramNamesOf(method.Params),
+		)
+	}
+	return sb.String()
+}
+
+func tsType(t string) string {
+	if e, ok := elemType(t); ok {
+		return tsType(e) + "[]"
+	}
+	switch t {
+	case "TreeNode", "ListNode":
+		return t + " | null"
+	}
+	return jsType(t)
+}
+
+func tsParams(params []leetcode.MetaDataParam) string {
+	return joinParams(
+		params, func(p leetcode.MetaDataParam) string {
+			return p.Name + ": " + tsType(p.Type)
+		},
+	)
+}
+
+func synthesizeTypeScript(m *leetcode.MetaData) string {
+	if !m.SystemDesign {
+		return fmt.Sprintf(
+			"function %s(%s): %s {\n\n};",
+			m.Name, tsParams(m.Params), tsType(returnTypeOf(m.Return)),
+		)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "class %s {\n    constructor(%s) {\n\n    }\n", m.ClassName, tsParams(m.Constructor.Params))
+	for _, method := range m.Methods {
+		fmt.Fprintf(
+			&sb, "\n    %s(%s): %s {\n\n    }\n",
+			method.Name, tsParams(method.Params), tsType(method.Return.Type),
+		)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+var kotlinArrayTypes = map[string]string{
+	"integer":   "IntArray",
+	"long":      "LongArray",
+	"double":    "DoubleArray",
+	"boolean":   "BooleanArray",
+	"character": "CharArray",
+}
+
+func kotlinType(t string) string {
+	if e, ok := elemType(t); ok {
+		if arr, ok := kotlinArrayTypes[e]; ok {
+			return arr
+		}
+		return "Array<" + kotlinType(e) + ">"
+	}
+	switch t {
+	case "integer":
+		return "Int"
+	case "long":
+		return "Long"
+	case "double":
+		return "Double"
+	case "boolean":
+		return "Boolean"
+	case "character":
+		return "Char"
+	case "string":
+		return "String"
+	case "void":
+		return "Unit"
+	case "TreeNode", "ListNode":
+		return t + "?"
+	}
+	return t
+}
+
+func kotlinParams(params []leetcode.MetaDataParam) string {
+	return joinParams(
+		params, func(p leetcode.MetaDataParam) string {
+			return p.Name + ": " + kotlinType(p.Type)
+		},
+	)
+}
+
+func kotlinResult(t string) string {
+	if t == "void" {
+		return ""
+	}
+	return ": " + kotlinType(t)
+}
+
+func synthesizeKotlin(m *leetcode.MetaData) string {
+	if !m.SystemDesign {
+		return fmt.Sprintf(
+			"class Solution {\n    fun %s(%s)%s {\n        \n    }\n}",
+			m.Name, kotlinParams(m.Params), kotlinResult(returnTypeOf(m.Return)),
+		)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "class %s(%s) {\n", m.ClassName, kotlinParams(m.Constructor.Params))
+	for _, method := range m.Methods {
+		fmt.Fprintf(
+			&sb, "\n    fun %s(%s)%s {\n        \n    }\n",
+			method.Name, kotlinParams(method.Params), kotlinResult(method.Return.Type),
+		)
+	}
+	sb.WriteString("\n}")
+	return sb.String()
+}
+
+func csharpType(t string) string {
+	if e, ok := elemType(t); ok {
+		return csharpType(e) + "[]"
+	}
+	switch t {
+	case "integer":
+		return "int"
+	case "boolean":
+		return "bool"
+	case "character":
+		return "char"
+	}
+	return t
+}
+
+func csharpParams(params []leetcode.MetaDataParam) string {
+	return joinParams(
+		params, func(p leetcode.MetaDataParam) string {
+			return csharpType(p.Type) + " " + p.Name
+		},
+	)
+}
+
+func synthesizeCSharp(m *leetcode.MetaData) string {
+	if !m.SystemDesign {
+		return fmt.Sprintf(
+			"public class Solution {\n    public %s %s(%s) {\n        \n    }\n}",
+			csharpType(returnTypeOf(m.Return)), utils.PascalCase(m.Name), csharpParams(m.Params),
+		)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(
+		&sb, "public class %s {\n\n    public %s(%s) {\n        \n    }\n",
+		m.ClassName, m.ClassName, csharpParams(m.Constructor.Params),
+	)
+	for _, method := range m.Methods {
+		fmt.Fprintf(
+			&sb, "    \n    public %s %s(%s) {\n        \n    }\n",
+			csharpType(method.Return.Type), utils.PascalCase(method.Name), csharpParams(method.Params),
+		)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func swiftType(t string) string {
+	if e, ok := elemType(t); ok {
+		return "[" + swiftType(e) + "]"
+	}
+	switch t {
+	case "integer", "long":
+		return "Int"
+	case "double":
+		return "Double"
+	case "boolean":
+		return "Bool"
+	case "character":
+		return "Character"
+	case "string":
+		return "String"
+	case "TreeNode", "ListNode":
+		return t + "?"
+	}
+	return t
+}
+
+func swiftSignature(params []leetcode.MetaDataParam, ret string) string {
+	s := "(" + joinParams(
+		params, func(p leetcode.MetaDataParam) string {
+			return "_ " + p.Name + ": " + swiftType(p.Type)
+		},
+	) + ")"
+	if ret != "void" && ret != "" {
+		s += " -> " + swiftType(ret)
+	}
+	return s
+}
+
+func synthesizeSwift(m *leetcode.MetaData) string {
+	if !m.SystemDesign {
+		return fmt.Sprintf(
+			"class Solution {\n    func %s%s {\n        \n    }\n}",
+			m.Name, swiftSignature(m.Params, returnTypeOf(m.Return)),
+		)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "class %s {\n\n    init%s {\n        \n    }\n", m.ClassName, swiftSignature(m.Constructor.Params, ""))
+	for _, method := range m.Methods {
+		fmt.Fprintf(&sb, "    \n    func %s%s {\n        \n    }\n", method.Name, swiftSignature(method.Params, method.Return.Type))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func phpType(t string) string {
+	if e, ok := elemType(t); ok {
+		return phpType(e) + "[]"
+	}
+	switch t {
+	case "integer", "long":
+		return "Integer"
+	case "double":
+		return "Float"
+	case "boolean":
+		return "Boolean"
+	case "character", "string":
+		return "String"
+	case "void":
+		return "NULL"
+	}
+	return t
+}
+
+func phpFunction(name string, params []leetcode.MetaDataParam, ret string) string {
+	var sb strings.Builder
+	sb.WriteString("    /**\n")
+	for _, p := range params {
+		fmt.Fprintf(&sb, "     * @param %s $%s\n", phpType(p.Type), p.Name)
+	}
+	if ret != "" {
+		fmt.Fprintf(&sb, "     * @return %s\n", phpType(ret))
+	}
+	sb.WriteString("     */\n")
+	args := joinParams(
+		params, func(p leetcode.MetaDataParam) string {
+			return "$" + p.Name
+		},
+	)
+	fmt.Fprintf(&sb, "    function %s(%s) {\n        \n    }\n", name, args)
+	return sb.String()
+}
+
+func synthesizePHP(m *leetcode.MetaData) string {
+	if !m.SystemDesign {
+		return "class Solution {\n\n" + phpFunction(m.Name, m.Params, returnTypeOf(m.Return)) + "}"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "class %s {\n", m.ClassName)
+	sb.WriteString(phpFunction("__construct", m.Constructor.Params, ""))
+	for _, method := range m.Methods {
+		sb.WriteString("  \n" + phpFunction(method.Name, method.Params, method.Return.Type))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func rubyFunction(indent string, name string, params []leetcode.MetaDataParam, ret string) string {
+	var sb strings.Builder
+	for _, p := range params {
+		fmt.Fprintf(&sb, "%s# @param {%s} %s\n", indent, phpType(p.Type), p.Name)
+	}
+	if ret != "" {
+		fmt.Fprintf(&sb, "%s# @return {%s}\n", indent, phpType(ret))
+	}
+	args := joinParams(
+		params, func(p leetcode.MetaDataParam) string {
+			return utils.SnakeCase(p.Name)
+		},
+	)
+	fmt.Fprintf(&sb, "%sdef %s(%s)\n%s    \n%send\n", indent, name, args, indent, indent)
+	return sb.String()
+}
+
+func synthesizeRuby(m *leetcode.MetaData) string {
+	if !m.SystemDesign {
+		return rubyFunction("", utils.SnakeCase(m.Name), m.Params, returnTypeOf(m.Return))
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "class %s\n\n", m.ClassName)
+	sb.WriteString(rubyFunction("    ", "initialize", m.Constructor.Params, ""))
+	for _, method := range m.Methods {
+		sb.WriteString("\n" + rubyFunction("    ", utils.SnakeCase(method.Name), method.Params, method.Return.Type))
+	}
+	sb.WriteString("\nend")
+	return sb.String()
+}
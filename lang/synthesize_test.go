@@ -0,0 +1,107 @@
+package lang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"github.com/j178/leetgo/leetcode"
+)
+
+const (
+	twoSumMeta = `{"name": "twoSum", "params": [{"name": "nums", "type": "integer[]"}, {"name": "target", "type": "integer"}], "return": {"type": "integer[]"}}`
+	lruMeta    = `{"classname": "LRUCache", "constructor": {"params": [{"type": "integer", "name": "capacity"}]},
+"methods": [{"name": "get", "params": [{"type": "integer", "name": "key"}], "return": {"type": "integer"}},
+{"name": "put", "params": [{"type": "integer", "name": "key"}, {"type": "integer", "name": "value"}], "return": {"type": "void"}}],
+"return": {"type": "void"}, "systemdesign": true}`
+	groupMeta = `{"name": "groupAnagrams", "params": [{"name": "strs", "type": "list<string>"}], "return": {"type": "list<list<integer>>"}}`
+)
+
+func questionWithMeta(t *testing.T, meta string) *leetcode.QuestionData {
+	q := &leetcode.QuestionData{TitleSlug: "test"}
+	err := json.Unmarshal([]byte(meta), &q.MetaData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func TestSynthesizeSnippet(t *testing.T) {
+	testCases := []struct {
+		lang Lang
+		meta string
+		want []string
+	}{
+		{golangGen, twoSumMeta, []string{"func twoSum(nums []int, target int) []int {"}},
+		{golangGen, lruMeta, []string{"type LRUCache struct", "func Constructor(capacity int) LRUCache {", "func (this *LRUCache) Put(key int, value int) {"}},
+		{python3Gen, twoSumMeta, []string{"def twoSum(self, nums: List[int], target: int) -> List[int]:"}},
+		{python3Gen, lruMeta, []string{"def __init__(self, capacity: int):", "def put(self, key: int, value: int) -> None:"}},
+		{cppGen, twoSumMeta, []string{"vector<int> twoSum(vector<int>& nums, int target) {"}},
+		{cppGen, lruMeta, []string{"LRUCache(int capacity) {", "void put(int key, int value) {"}},
+		{javaGen, twoSumMeta, []string{"public int[] twoSum(int[] nums, int target) {"}},
+		{rustGen, twoSumMeta, []string{"pub fn two_sum(nums: Vec<i32>, target: i32) -> Vec<i32> {"}},
+		{rustGen, lruMeta, []string{"fn new(capacity: i32) -> Self {", "fn put(&mut self, key: i32, value: i32) {"}},
+		{jsGen, twoSumMeta, []string{" * @param {number[]} nums", "var twoSum = function(nums, target) {"}},
+		{tsGen, lruMeta, []string{"constructor(capacity: number) {", "put(key: number, value: number): void {"}},
+		{kotlinGen, twoSumMeta, []string{"fun twoSum(nums: IntArray, target: Int): IntArray {"}},
+		{csharpGen, twoSumMeta, []string{"public int[] TwoSum(int[] nums, int target) {"}},
+		{swiftGen, twoSumMeta, []string{"func twoSum(_ nums: [Int], _ target: Int) -> [Int] {"}},
+		{phpGen, twoSumMeta, []string{"* @param Integer[] $nums", "function twoSum($nums, $target) {"}},
+		{rubyGen, twoSumMeta, []string{"# @return {Integer[]}", "def two_sum(nums, target)"}},
+		{golangGen, groupMeta, []string{"func groupAnagrams(strs []string) [][]int {"}},
+		{javaGen, groupMeta, []string{"public int[][] groupAnagrams(String[] strs) {"}},
+	}
+	for _, tc := range testCases {
+		q := questionWithMeta(t, tc.meta)
+		code, err := SynthesizeSnippet(tc.lang, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(code, want) {
+				t.Errorf("%s: %q not found in\n%s", tc.lang.Slug(), want, code)
+			}
+		}
+	}
+
+	// Metadata normalizes list types, the type mappers handle them as well.
+	for _, tc := range []struct{ got, want string }{
+		{convertToGoType("list<list<integer>>"), "[][]int"},
+		{pythonType("list<string>"), "List[str]"},
+		{cppType("list<integer>"), "vector<int>"},
+		{javaType("list<integer>"), "int[]"},
+		{rustType("list<list<string>>"), "Vec<Vec<String>>"},
+		{kotlinType("list<integer>"), "IntArray"},
+		{swiftType("list<integer>"), "[Int]"},
+		{phpType("list<string>"), "String[]"},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("got %s, want %s", tc.got, tc.want)
+		}
+	}
+
+	_, err := SynthesizeSnippet(mysqlGen, questionWithMeta(t, twoSumMeta))
+	if err == nil {
+		t.Error("expected error for mysql")
+	}
+}
+
+func TestSynthesizedGoWorksWithModifiers(t *testing.T) {
+	for _, meta := range []string{twoSumMeta, lruMeta} {
+		q := questionWithMeta(t, meta)
+		code, err := SynthesizeSnippet(golangGen, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range []ModifierFunc{changeReceiverName, addNamedReturn, addMod} {
+			code, err = m(code, q)
+			if err != nil {
+				t.Fatalf("modifier failed on synthesized code: %v\n%s", err, code)
+			}
+		}
+		if strings.Contains(code, "this") {
+			t.Errorf("receiver not renamed:\n%s", code)
+		}
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
 	"github.com/j178/leetgo/config"
 	"github.com/j178/leetgo/leetcode"
 	goutils "github.com/j178/leetgo/testutils/go"
@@ -19,7 +22,111 @@ import (
 	"github.com/jedib0t/go-pretty/v6/list"
 )
 
-func RunLocalTest(q *leetcode.QuestionData) (bool, error) {
+type ProfileKind string
+
+const (
+	ProfileCPU ProfileKind = "cpu"
+	ProfileMem ProfileKind = "mem"
+)
+
+type testOptions struct {
+	profile ProfileKind
+	results *[]CaseResult
+	variant string
+}
+
+type TestOption func(*testOptions)
+
+func newTestOptions(opts []TestOption) testOptions {
+	var o testOptions
+	for _, f := range opts {
+		f(&o)
+	}
+	return o
+}
+
+// WithProfile profiles the solution on every test case, profiles are written into the question directory.
+func WithProfile(kind ProfileKind) TestOption {
+	return func(o *testOptions) {
+		o.profile = kind
+	}
+}
+
+// WithVariant tests the variant instead of the one selected by --variant, "" is the main solution.
+func WithVariant(variant string) TestOption {
+	return func(o *testOptions) {
+		o.variant = variant
+	}
+}
+
+// WithResults collects the result of every test case into results.
+func WithResults(results *[]CaseResult) TestOption {
+	return func(o *testOptions) {
+		o.results = results
+	}
+}
+
+type CaseStatus string
+
+const (
+	CaseSkipped           CaseStatus = "Skipped"
+	CaseFailedToStart     CaseStatus = "Failed to start"
+	CaseTimeLimitExceeded CaseStatus = "Time limit exceeded"
+	CaseRuntimeError      CaseStatus = "Runtime error"
+	CaseInvalidOutput     CaseStatus = "Invalid output"
+	CaseAccepted          CaseStatus = "Accepted"
+	CaseWrongAnswer       CaseStatus = "Wrong answer"
+)
+
+// CaseResult is the result of running a single test case locally.
+type CaseResult struct {
+	No       int
+	Input    []string
+	Output   string
+	Expected string
+	Stdout   string
+	Status   CaseStatus
+}
+
+// LocalCase is a test case read from testcases.txt.
+type LocalCase struct {
+	No     int
+	Input  []string
+	Output string
+}
+
+// GetLocalTestCases reads test cases from testcases.txt, only the target case is returned if target_case is set.
+func GetLocalTestCases(q *leetcode.QuestionData) ([]LocalCase, error) {
+	err := q.Fulfill()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question data: %w", err)
+	}
+	result, err := GeneratePathsOnly(q)
+	if err != nil {
+		return nil, err
+	}
+	f := result.GetFile(TestCasesFile)
+	if f == nil {
+		return nil, fmt.Errorf("language %s does not have a test cases file", result.Lang.Slug())
+	}
+	if !utils.IsExist(f.GetPath()) {
+		return nil, fmt.Errorf("test cases file %s not found", utils.RelToCwd(f.GetPath()))
+	}
+	tc, err := parseTestCases(q, f)
+	if err != nil {
+		return nil, err
+	}
+	var cases []LocalCase
+	for _, c := range tc.cases {
+		if tc.targetCase != 0 && c.no != tc.targetCase {
+			continue
+		}
+		cases = append(cases, LocalCase{No: c.no, Input: c.input, Output: c.output})
+	}
+	return cases, nil
+}
+
+func RunLocalTest(q *leetcode.QuestionData, opts ...TestOption) (bool, error) {
 	cfg := config.Get()
 	gen, err := GetGenerator(cfg.Code.Lang)
 	if err != nil {
@@ -29,6 +136,15 @@ func RunLocalTest(q *leetcode.QuestionData) (bool, error) {
 	if !ok {
 		return false, fmt.Errorf("language %s does not support local test", gen.Slug())
 	}
+	opts = append([]TestOption{WithVariant(currentVariant())}, opts...)
+	o := newTestOptions(opts)
+	err = checkVariant(o.variant)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := gen.(Profiler); o.profile != "" && !ok {
+		return false, fmt.Errorf("language %s does not support profiling", gen.Slug())
+	}
 	err = q.Fulfill()
 	if err != nil {
 		return false, fmt.Errorf("failed to get question data: %w", err)
@@ -38,7 +154,7 @@ func RunLocalTest(q *leetcode.QuestionData) (bool, error) {
 		return false, fmt.Errorf("no code generated for %s in language %s", q.TitleSlug, gen.Slug())
 	}
 
-	return tester.RunLocalTest(q, outDir)
+	return tester.RunLocalTest(q, outDir, opts...)
 }
 
 // typeNameToType converts a Go type name to reflect.Type.
@@ -249,6 +365,18 @@ func checkOutput(q *leetcode.QuestionData, outputLine string) error {
 	return nil
 }
 
+func showProfile(profiler Profiler, kind ProfileKind, file string, outDir string) {
+	args := profiler.ProfileReport(kind, file)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = outDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		log.Error("failed to show profile", "file", utils.RelToCwd(file), "err", err)
+		return
+	}
+	fmt.Printf("%s %s\n%s\n", kind, stdoutStyle.Render(utils.RelToCwd(file)), output)
+}
+
 var (
 	skippedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#b8b8b8"))
 	passedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#00b300"))
@@ -257,7 +385,19 @@ var (
 	stdoutStyle  = lipgloss.NewStyle().Faint(true)
 )
 
-func runTest(q *leetcode.QuestionData, genResult *GenerateResult, args []string, outDir string) (bool, error) {
+func runTest(
+	q *leetcode.QuestionData,
+	genResult *GenerateResult,
+	args []string,
+	outDir string,
+	opts ...TestOption,
+) (bool, error) {
+	o := newTestOptions(opts)
+	profiler, _ := genResult.Lang.(Profiler)
+	if o.profile != "" && profiler == nil {
+		return false, fmt.Errorf("language %s does not support profiling", genResult.Lang.Slug())
+	}
+
 	testcaseFile := genResult.GetFile(TestCasesFile)
 	if testcaseFile == nil {
 		panic("no test cases file generated")
@@ -275,6 +415,21 @@ func runTest(q *leetcode.QuestionData, genResult *GenerateResult, args []string,
 		passed    int
 	)
 	for _, c := range tc.cases {
+		profileFile := ""
+		record := func(status CaseStatus, output string, stdout string) {
+			if o.results != nil {
+				*o.results = append(
+					*o.results, CaseResult{
+						No:       c.no,
+						Input:    c.input,
+						Output:   output,
+						Expected: c.output,
+						Stdout:   stdout,
+						Status:   status,
+					},
+				)
+			}
+		}
 		func() {
 			l := list.NewWriter()
 			l.SetStyle(list.StyleBulletCircle)
@@ -282,6 +437,7 @@ func runTest(q *leetcode.QuestionData, genResult *GenerateResult, args []string,
 				fmt.Println(l.Render())
 			}()
 			if tc.targetCase != 0 && c.no != tc.targetCase {
+				record(CaseSkipped, "", "")
 				l.AppendItem(fmt.Sprintf("Case %d:    %s", c.no, skippedStyle.Render("Skipped")))
 				return
 			}
@@ -295,8 +451,13 @@ func runTest(q *leetcode.QuestionData, genResult *GenerateResult, args []string,
 			cmd.Stdin = strings.NewReader(c.Input())
 			cmd.Stdout = &outputBuf
 			cmd.Stderr = &outputBuf
+			if o.profile != "" {
+				profileFile = filepath.Join(genResult.CodeDir(), fmt.Sprintf("%s.case%d.pprof", o.profile, c.no))
+				cmd.Env = append(os.Environ(), profiler.ProfileEnv(o.profile, profileFile)...)
+			}
 			err = cmd.Start()
 			if err != nil {
+				record(CaseFailedToStart, "", "")
 				l.AppendItem(fmt.Sprintf("Case %d:    %s", c.no, errorStyle.Render("Failed to start")))
 				return
 			}
@@ -316,6 +477,7 @@ func runTest(q *leetcode.QuestionData, genResult *GenerateResult, args []string,
 				}
 			}
 			if ctx.Err() != nil {
+				record(CaseTimeLimitExceeded, "", stdout)
 				l.AppendItem(fmt.Sprintf("Case %d:    %s", c.no, errorStyle.Render("Time limit exceeded")))
 				l.Indent()
 				l.AppendItem(fmt.Sprintf("Input:      %s", strings.ReplaceAll(c.Input(), "\n", "↩ ")))
@@ -324,6 +486,7 @@ func runTest(q *leetcode.QuestionData, genResult *GenerateResult, args []string,
 				return
 			}
 			if err != nil {
+				record(CaseRuntimeError, "", stdout)
 				l.AppendItem(fmt.Sprintf("Case %d:    %s", c.no, errorStyle.Render("Runtime error")))
 				l.Indent()
 				l.AppendItem(fmt.Sprintf("Input:      %s", strings.ReplaceAll(c.Input(), "\n", "↩ ")))
@@ -333,6 +496,7 @@ func runTest(q *leetcode.QuestionData, genResult *GenerateResult, args []string,
 			}
 			err = checkOutput(q, actualOutput)
 			if err != nil {
+				record(CaseInvalidOutput, actualOutput, stdout)
 				l.AppendItem(fmt.Sprintf("Case %d:    %s", c.no, errorStyle.Render("Invalid output")))
 				l.Indent()
 				l.AppendItem(fmt.Sprintf("Input:      %s", strings.ReplaceAll(c.Input(), "\n", "↩ ")))
@@ -344,8 +508,10 @@ func runTest(q *leetcode.QuestionData, genResult *GenerateResult, args []string,
 
 			if judgeResult(q, actualOutput, c.output) {
 				passed++
+				record(CaseAccepted, actualOutput, stdout)
 				l.AppendItem(fmt.Sprintf("Case %d:    %s", c.no, passedStyle.Render("Accepted")))
 			} else {
+				record(CaseWrongAnswer, actualOutput, stdout)
 				l.AppendItem(fmt.Sprintf("Case %d:    %s", c.no, failedStyle.Render("Wrong answer")))
 				l.Indent()
 				l.AppendItem(fmt.Sprintf("Input:      %s", strings.ReplaceAll(c.Input(), "\n", "↩ ")))
@@ -355,6 +521,9 @@ func runTest(q *leetcode.QuestionData, genResult *GenerateResult, args []string,
 				l.UnIndent()
 			}
 		}()
+		if profileFile != "" && utils.IsExist(profileFile) {
+			showProfile(profiler, o.profile, profileFile, outDir)
+		}
 	}
 	if passed == ran {
 		return true, nil
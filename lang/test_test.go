@@ -0,0 +1,46 @@
+package lang
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/j178/leetgo/leetcode"
+)
+
+func TestRunTestCountsCases(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs sh")
+	}
+	q := &leetcode.QuestionData{
+		MetaData: leetcode.MetaData{
+			Name:   "echo",
+			Params: []leetcode.MetaDataParam{{Name: "x", Type: "integer"}},
+			Return: &leetcode.MetaDataReturn{Type: "integer"},
+		},
+	}
+	// The program echoes its input as the answer.
+	args := []string{"sh", "-c", `read x; echo "output: $x"`}
+	run := func(testcases string) (bool, []CaseResult) {
+		result := &GenerateResult{OutDir: t.TempDir()}
+		result.AddFile(FileOutput{Filename: "testcases.txt", Type: TestCasesFile, Content: testcases})
+		var results []CaseResult
+		ok, err := runTest(q, result, args, result.OutDir, WithResults(&results))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ok, results
+	}
+
+	ok, results := run("input:\n1\noutput:\n1\n")
+	if !ok || len(results) != 1 || results[0].Status != CaseAccepted {
+		t.Errorf("a single accepted case should pass, got %v %+v", ok, results)
+	}
+
+	ok, results = run("input:\n1\noutput:\n1\n\ninput:\n2\noutput:\n3\n")
+	if ok {
+		t.Error("one accepted and one wrong case should fail")
+	}
+	if len(results) != 2 || results[0].Status != CaseAccepted || results[1].Status != CaseWrongAnswer {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
@@ -0,0 +1,46 @@
+package lang
+
+import (
+	"fmt"
+	"text/template"
+
+	"github.com/j178/leetgo/config"
+)
+
+// ValidateTemplates loads the templates, blocks and modifier scripts of the configured languages,
+// so that mistakes are reported before any question is generated.
+func ValidateTemplates() error {
+	knownModifiers := make(map[string]ModifierFunc)
+	for name, f := range builtinModifiers {
+		knownModifiers[name] = f
+	}
+	for name, f := range goBuiltinModifiers {
+		knownModifiers[name] = f
+	}
+	noop := func(s string) string { return s }
+
+	for _, l := range config.Get().Code.AllLangs() {
+		gen, err := GetGenerator(l)
+		if err != nil {
+			// Unknown languages are reported by the commands that use them.
+			continue
+		}
+		root, err := getRootTemplate(gen)
+		if err != nil {
+			return fmt.Errorf("%s: %w", gen.Slug(), err)
+		}
+		blocks, err := getBlocks(gen)
+		if err != nil {
+			return fmt.Errorf("%s: %w", gen.Slug(), err)
+		}
+		_, err = newCodeTemplate(root, blocks, template.FuncMap{"runModifiers": noop, "comment": noop})
+		if err != nil {
+			return fmt.Errorf("%s: %w", gen.Slug(), err)
+		}
+		_, err = getModifiers(gen, knownModifiers)
+		if err != nil {
+			return fmt.Errorf("%s: %w", gen.Slug(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package lang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/leetcode"
+	"github.com/j178/leetgo/utils"
+)
+
+// A variant is an alternative solution of the same question, e.g. "dp" or "greedy".
+// Languages that generate a directory per question put the variant into a subdirectory,
+// e.g. "0322.coin-change/dp/solution.go", others add it before the extension, e.g. "0322.coin-change.dp.cpp".
+// Test cases and description are shared by all variants.
+
+// MainVariant is the name of the default solution in reports.
+const MainVariant = "main"
+
+var validVariant = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// currentVariant returns the variant selected by the --variant flag.
+func currentVariant() string {
+	return viper.GetString("variant")
+}
+
+func checkVariant(variant string) error {
+	if variant == MainVariant {
+		return fmt.Errorf("variant name %q is reserved for the main solution", variant)
+	}
+	if variant != "" && !validVariant.MatchString(variant) {
+		return fmt.Errorf("invalid variant %q, only letters, digits, - and _ are allowed", variant)
+	}
+	return nil
+}
+
+// packageLang is implemented by languages whose code files live in the directory of their package,
+// every variant gets its own package and directory.
+type packageLang interface {
+	variantFilename(filename string, variant string) string
+}
+
+// variantFilename returns the filename of the code file of a variant, relative to the SubDir of result.
+func variantFilename(result *GenerateResult, filename string, variant string) string {
+	if p, ok := result.Lang.(packageLang); ok {
+		return p.variantFilename(filename, variant)
+	}
+	if result.SubDir != "" {
+		return filepath.Join(variant, filename)
+	}
+	ext := filepath.Ext(filename)
+	return strings.TrimSuffix(filename, ext) + "." + variant + ext
+}
+
+// applyVariant moves the code files of the result to where the variant lives.
+func applyVariant(result *GenerateResult, variant string) {
+	if variant == "" {
+		return
+	}
+	result.Variant = variant
+	for i, f := range result.Files {
+		if f.Type&(CodeFile|TestFile) == 0 {
+			continue
+		}
+		result.Files[i].Filename = variantFilename(result, f.Filename, variant)
+	}
+}
+
+// CodeDir returns the directory that contains the code file.
+func (r *GenerateResult) CodeDir() string {
+	if f := r.GetFile(CodeFile); f != nil {
+		return filepath.Dir(f.GetPath())
+	}
+	return filepath.Join(r.OutDir, r.SubDir)
+}
+
+func generateVariantPaths(gen Lang, q *leetcode.QuestionData, variant string) (*GenerateResult, error) {
+	err := checkVariant(variant)
+	if err != nil {
+		return nil, err
+	}
+	result, err := gen.GeneratePaths(q)
+	if err != nil {
+		return nil, err
+	}
+	applyVariant(result, variant)
+	result.SetOutDir(getOutDir(q, gen))
+	return result, nil
+}
+
+// Variants returns the variants of the question that have been generated, the main solution is "".
+func Variants(q *leetcode.QuestionData) ([]string, error) {
+	cfg := config.Get()
+	gen, err := GetGenerator(cfg.Code.Lang)
+	if err != nil {
+		return nil, err
+	}
+	result, err := generateVariantPaths(gen, q, "")
+	if err != nil {
+		return nil, err
+	}
+	codeFile := result.GetFile(CodeFile)
+	if codeFile == nil {
+		return nil, fmt.Errorf("no code file generated")
+	}
+
+	var variants []string
+	if utils.IsExist(codeFile.GetPath()) {
+		variants = append(variants, "")
+	}
+	var found []string
+	if result.SubDir != "" {
+		entries, err := os.ReadDir(filepath.Join(result.OutDir, result.SubDir))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.IsDir() && validVariant.MatchString(e.Name()) &&
+				utils.IsExist(filepath.Join(result.OutDir, result.SubDir, e.Name(), codeFile.Filename)) {
+				found = append(found, e.Name())
+			}
+		}
+	} else {
+		ext := filepath.Ext(codeFile.Filename)
+		prefix := strings.TrimSuffix(filepath.Base(codeFile.Filename), ext) + "."
+		dir := filepath.Dir(codeFile.Filename)
+		if _, ok := gen.(packageLang); ok {
+			dir += "_*"
+		}
+		matches, err := filepath.Glob(filepath.Join(result.OutDir, dir, prefix+"*"+ext))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			v := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(m), prefix), ext)
+			if validVariant.MatchString(v) &&
+				filepath.Join(result.OutDir, variantFilename(result, codeFile.Filename, v)) == m {
+				found = append(found, v)
+			}
+		}
+	}
+	sort.Strings(found)
+	return append(variants, found...), nil
+}
+
+// VariantName returns the display name of a variant.
+func VariantName(variant string) string {
+	if variant == "" {
+		return MainVariant
+	}
+	return variant
+}
+
+// VariantRow lines up the answers of all variants on a single test case.
+type VariantRow struct {
+	No       int
+	Input    string
+	Outputs  []string
+	Mismatch bool
+}
+
+// VariantComparison is the result of running all variants of a question locally.
+type VariantComparison struct {
+	Variants []string
+	Passed   []bool
+	Rows     []VariantRow
+}
+
+// AllPassed reports whether every variant passed and all of them agree with each other.
+func (c *VariantComparison) AllPassed() bool {
+	for _, p := range c.Passed {
+		if !p {
+			return false
+		}
+	}
+	for _, r := range c.Rows {
+		if r.Mismatch {
+			return false
+		}
+	}
+	return true
+}
+
+// CompareVariants runs local test against every variant of the question,
+// and compares their answers case by case.
+func CompareVariants(q *leetcode.QuestionData, opts ...TestOption) (*VariantComparison, error) {
+	variants, err := Variants(q)
+	if err != nil {
+		return nil, err
+	}
+	if len(variants) < 2 {
+		return nil, fmt.Errorf("only %d variant found for %s, nothing to compare", len(variants), q.TitleSlug)
+	}
+
+	cmp := &VariantComparison{Variants: variants}
+	results := make([]map[int]CaseResult, len(variants))
+	var caseNos []int
+	for i, v := range variants {
+		var caseResults []CaseResult
+		passed, err := RunLocalTest(q, append(opts, WithVariant(v), WithResults(&caseResults))...)
+		if err != nil {
+			return nil, fmt.Errorf("variant %s: %w", VariantName(v), err)
+		}
+		cmp.Passed = append(cmp.Passed, passed)
+		results[i] = make(map[int]CaseResult, len(caseResults))
+		for _, r := range caseResults {
+			if r.Status == CaseSkipped {
+				continue
+			}
+			if i == 0 {
+				caseNos = append(caseNos, r.No)
+			}
+			results[i][r.No] = r
+		}
+	}
+
+	for _, no := range caseNos {
+		first := results[0][no]
+		row := VariantRow{No: no, Input: strings.Join(first.Input, "\n")}
+		for i := range variants {
+			r, ok := results[i][no]
+			output := r.Output
+			if !ok {
+				output = string(CaseSkipped)
+			} else if output == "" {
+				output = string(r.Status)
+			}
+			row.Outputs = append(row.Outputs, output)
+			if i > 0 && !judgeResult(q, output, row.Outputs[0]) {
+				row.Mismatch = true
+			}
+		}
+		cmp.Rows = append(cmp.Rows, row)
+	}
+	return cmp, nil
+}
@@ -0,0 +1,69 @@
+package lang
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/j178/leetgo/leetcode"
+)
+
+func TestApplyVariant(t *testing.T) {
+	dirResult := &GenerateResult{SubDir: "0322.coin-change"}
+	dirResult.AddFile(FileOutput{Filename: "solution.go", Type: CodeFile | TestFile})
+	dirResult.AddFile(FileOutput{Filename: "testcases.txt", Type: TestCasesFile})
+	applyVariant(dirResult, "dp")
+	if got := dirResult.Files[0].Filename; got != "dp/solution.go" && got != `dp\solution.go` {
+		t.Errorf("unexpected code file: %s", got)
+	}
+	if got := dirResult.Files[1].Filename; got != "testcases.txt" {
+		t.Errorf("test cases file should be shared, got %s", got)
+	}
+
+	flatResult := &GenerateResult{}
+	flatResult.AddFile(FileOutput{Filename: "0322.coin-change.cpp", Type: CodeFile})
+	flatResult.AddFile(FileOutput{Filename: "0322.coin-change.md", Type: DocFile})
+	applyVariant(flatResult, "greedy")
+	if got := flatResult.Files[0].Filename; got != "0322.coin-change.greedy.cpp" {
+		t.Errorf("unexpected code file: %s", got)
+	}
+	if got := flatResult.Files[1].Filename; got != "0322.coin-change.md" {
+		t.Errorf("description file should be shared, got %s", got)
+	}
+}
+
+func TestJavaVariantPackage(t *testing.T) {
+	q := questionWithMeta(t, twoSumMeta)
+	q.TitleSlug = "two-sum"
+	q.QuestionFrontendId = "1"
+	q.CodeSnippets = []leetcode.CodeSnippet{{LangSlug: javaGen.Slug(), Code: "class Solution {\n}\n"}}
+	// The default header needs a client for the question URL.
+	viper.Set("code.java.blocks", []any{map[string]any{"name": "header", "template": "// header\n"}})
+	defer viper.Set("code.java.blocks", nil)
+
+	generate := func(variant string) FileOutput {
+		viper.Set("variant", variant)
+		defer viper.Set("variant", "")
+		result, err := javaGen.Generate(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		applyVariant(result, variant)
+		return *result.GetFile(CodeFile)
+	}
+	main, dp := generate(""), generate("dp")
+
+	for _, tc := range []struct {
+		file FileOutput
+		pkg  string
+	}{{main, "q0001_two_sum"}, {dp, "q0001_two_sum_dp"}} {
+		if !strings.Contains(tc.file.Content, "package "+tc.pkg+";") {
+			t.Errorf("package %s not declared in\n%s", tc.pkg, tc.file.Content)
+		}
+		if filepath.Dir(tc.file.Filename) != tc.pkg {
+			t.Errorf("%s should be in the directory of package %s", tc.file.Filename, tc.pkg)
+		}
+	}
+}
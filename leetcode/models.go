@@ -75,6 +75,7 @@ type SubmitCheckResult struct {
 	CompileError      string  `json:"compile_error"`
 	FullCompileError  string  `json:"full_compile_error"`
 	FullRuntimeError  string  `json:"full_runtime_error"`
+	Cached            bool    `json:"-"` // loaded from the local result cache
 }
 
 var (
@@ -85,7 +86,25 @@ var (
 	colorRed    = color.New(color.FgHiRed, color.Bold)
 )
 
+// markCached appends a "(cached)" marker to the status line of a displayed result.
+func markCached(s string, cached bool) string {
+	if !cached {
+		return s
+	}
+	start := len(s) - len(strings.TrimLeft(s, "\n"))
+	end := strings.IndexByte(s[start:], '\n')
+	if end < 0 {
+		return s + colorFaint.Sprint(" (cached)")
+	}
+	end += start
+	return s[:end] + colorFaint.Sprint(" (cached)") + s[end:]
+}
+
 func (r *SubmitCheckResult) Display(q *QuestionData) string {
+	return markCached(r.display(q), r.Cached)
+}
+
+func (r *SubmitCheckResult) display(q *QuestionData) string {
 	stdout := ""
 	if len(r.CodeOutput) > 1 {
 		stdout = "\nStdout:        " + strings.ReplaceAll(r.StdOutput, "\n", "↩ ")
@@ -178,6 +197,7 @@ type RunCheckResult struct {
 	ExpectedStdOutputList  []string `json:"expected_std_output_list"`
 	ExpectedTaskFinishTime int      `json:"expected_task_finish_time"`
 	ExpectedTaskName       string   `json:"expected_task_name"`
+	Cached                 bool     `json:"-"` // loaded from the local result cache
 }
 
 func formatCompare(s string) string {
@@ -185,6 +205,8 @@ func formatCompare(s string) string {
 	for _, c := range s {
 		if c == '1' {
 			sb.WriteString(colorGreen.Sprint("√"))
+		} else if c == CaseNotRun {
+			sb.WriteString(colorFaint.Sprint("-"))
 		} else {
 			sb.WriteString(colorRed.Sprint("×"))
 		}
@@ -193,6 +215,10 @@ func formatCompare(s string) string {
 }
 
 func (r *RunCheckResult) Display(q *QuestionData) string {
+	return markCached(r.display(q), r.Cached)
+}
+
+func (r *RunCheckResult) display(q *QuestionData) string {
 	stdout := ""
 	if len(r.CodeOutput) > 1 {
 		stdout = "\nStdout:        " + strings.Join(r.CodeOutput, "↩ ")
@@ -244,6 +270,54 @@ func (r *RunCheckResult) GetState() string {
 	return r.State
 }
 
+// CaseNotRun marks a case in CompareResult that was not run, because the run stopped before it.
+const CaseNotRun = '-'
+
+// PadCases aligns the per-case results of a run of n cases. A run that stops early, e.g. on a runtime error
+// or time limit exceeded, has results of fewer cases, the missing ones are marked as not run.
+func (r *RunCheckResult) PadCases(n int) {
+	if len(r.CompareResult) < n {
+		r.CompareResult += strings.Repeat(string(CaseNotRun), n-len(r.CompareResult))
+	}
+	r.CompareResult = r.CompareResult[:n]
+	r.CodeAnswer = padCases(r.CodeAnswer, n)
+	r.ExpectedCodeAnswer = padCases(r.ExpectedCodeAnswer, n)
+	r.StdOutputList = padCases(r.StdOutputList, n)
+}
+
+func padCases(s []string, n int) []string {
+	for len(s) < n {
+		s = append(s, "")
+	}
+	return s[:n]
+}
+
+// Merge merges the result of another batch of test cases into r.
+// Both results should be padded by PadCases, so that cases of later batches keep their positions.
+func (r *RunCheckResult) Merge(o *RunCheckResult) {
+	if r.InputData != "" && o.InputData != "" {
+		r.InputData += "\n"
+	}
+	r.InputData += o.InputData
+	// Keep the first failure.
+	if StatusCode(r.StatusCode) == Accepted && StatusCode(o.StatusCode) != Accepted {
+		r.StatusCode = o.StatusCode
+		r.StatusMsg = o.StatusMsg
+		r.FullCompileError = o.FullCompileError
+		r.FullRuntimeError = o.FullRuntimeError
+	}
+	r.CorrectAnswer = r.CorrectAnswer && o.CorrectAnswer
+	r.Cached = r.Cached && o.Cached
+	r.CompareResult += o.CompareResult
+	r.CodeAnswer = append(r.CodeAnswer, o.CodeAnswer...)
+	r.CodeOutput = append(r.CodeOutput, o.CodeOutput...)
+	r.StdOutputList = append(r.StdOutputList, o.StdOutputList...)
+	r.ExpectedCodeAnswer = append(r.ExpectedCodeAnswer, o.ExpectedCodeAnswer...)
+	r.TotalCorrect += o.TotalCorrect
+	r.TotalTestcases += o.TotalTestcases
+	r.ElapsedTime += o.ElapsedTime
+}
+
 type QuestionList struct {
 	Questions []*QuestionData `json:"questions"`
 	HasMore   bool            `json:"hasMore"`
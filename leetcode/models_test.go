@@ -0,0 +1,52 @@
+package leetcode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeBatches(t *testing.T) {
+	// The first batch stops at its second case with a runtime error.
+	first := &RunCheckResult{
+		StatusCode:         int(RuntimeError),
+		StatusMsg:          "Runtime Error",
+		CompareResult:      "1",
+		CodeAnswer:         []string{"1"},
+		ExpectedCodeAnswer: []string{"1", "2", "3"},
+	}
+	second := &RunCheckResult{
+		StatusCode:         int(Accepted),
+		CompareResult:      "10",
+		CodeAnswer:         []string{"4", "0"},
+		ExpectedCodeAnswer: []string{"4", "5"},
+	}
+	first.PadCases(3)
+	second.PadCases(2)
+	first.Merge(second)
+
+	if first.CompareResult != "1--10" {
+		t.Errorf("unexpected compare result %q", first.CompareResult)
+	}
+	wantAnswers := []string{"1", "", "", "4", "0"}
+	wantExpected := []string{"1", "2", "3", "4", "5"}
+	for i := range wantAnswers {
+		if first.CodeAnswer[i] != wantAnswers[i] || first.ExpectedCodeAnswer[i] != wantExpected[i] {
+			t.Fatalf("case %d: got %q/%q, want %q/%q",
+				i+1, first.CodeAnswer[i], first.ExpectedCodeAnswer[i], wantAnswers[i], wantExpected[i])
+		}
+	}
+	if StatusCode(first.StatusCode) != RuntimeError {
+		t.Errorf("the first failure should be kept, got %d", first.StatusCode)
+	}
+}
+
+func TestDisplayCached(t *testing.T) {
+	r := &RunCheckResult{StatusCode: int(CompileError), StatusMsg: "Compile Error", FullCompileError: "line 1"}
+	if strings.Contains(r.Display(nil), "(cached)") {
+		t.Error("a fresh result should not be marked as cached")
+	}
+	r.Cached = true
+	if got := r.Display(nil); !strings.Contains(got, "Compile Error (cached)\n") {
+		t.Errorf("the status line should be marked as cached, got %q", got)
+	}
+}
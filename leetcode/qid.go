@@ -3,9 +3,12 @@ package leetcode
 import (
 	"errors"
 	"fmt"
+	"math/rand"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/j178/leetgo/config"
 )
@@ -40,24 +43,91 @@ func QuestionBySlug(slug string, c Client) (*QuestionData, error) {
 	return q, err
 }
 
+// validQIDForms is shown when a qid can't be resolved.
+const validQIDForms = `valid qid forms are:
+  two-sum, 1             question slug or ID
+  1-20, 1,15,42          range of IDs, comma separated list of qids
+  today                  daily question
+  last, last~2           last generated question, the one generated 2 questions before it
+  w330/1, b100/, last/   contest questions
+  list:blind75           questions of a study list
+  tag:graph:medium       cached questions of a tag, difficulty (easy, medium, hard) and status (solved, unsolved, tried)
+  random, random:medium  a random cached question, optionally filtered like tag:...`
+
+var (
+	qidRangePat = regexp.MustCompile(`^(\d+)-(\d+)$`)
+	lastQIDPat  = regexp.MustCompile(`^last(?:~(\d+))?$`)
+)
+
+const (
+	tagQIDPrefix        = "tag:"
+	difficultyQIDPrefix = "difficulty:"
+	randomQID           = "random"
+)
+
+// ParseLastQID returns the generated question that "last" or "last~N" refers to,
+// isLast reports whether qid is in one of these forms.
+func ParseLastQID(qid string) (last config.LastQuestion, isLast bool, err error) {
+	m := lastQIDPat.FindStringSubmatch(qid)
+	if m == nil {
+		return last, false, nil
+	}
+	n := 0
+	if m[1] != "" {
+		n, _ = strconv.Atoi(m[1])
+	}
+	last, ok := config.LoadState().RecentQuestion(n)
+	if !ok {
+		if n == 0 {
+			return last, true, errors.New("no last generated question")
+		}
+		return last, true, fmt.Errorf("only %d questions in the generation history", len(config.LoadState().History))
+	}
+	return last, true, nil
+}
+
+// ParseQID resolves qid to questions, errors list the valid qid forms.
 func ParseQID(qid string, c Client) ([]*QuestionData, error) {
+	qs, err := parseQID(qid, c)
+	if err != nil {
+		return nil, fmt.Errorf("%w\n%s", err, validQIDForms)
+	}
+	return qs, nil
+}
+
+func parseQID(qid string, c Client) ([]*QuestionData, error) {
+	if strings.Contains(qid, ",") {
+		return parseQIDList(qid, c)
+	}
+
 	var (
 		q   *QuestionData
 		qs  []*QuestionData
 		err error
 	)
 	switch {
+	case qidRangePat.MatchString(qid):
+		qs, err = questionsInRange(qid, c)
 	case isNumber(qid):
 		q, err = QuestionFromCacheByID(qid, c)
-	case qid == "last":
-		state := config.LoadState()
-		if state.LastQuestion.Slug != "" {
-			q, err = QuestionBySlug(state.LastQuestion.Slug, c)
-		} else {
-			err = errors.New("no last generated question")
+	case lastQIDPat.MatchString(qid):
+		var last config.LastQuestion
+		last, _, err = ParseLastQID(qid)
+		if err == nil {
+			q, err = QuestionBySlug(last.Slug, c)
 		}
 	case qid == "today":
 		q, err = c.GetTodayQuestion()
+	case qid == randomQID || strings.HasPrefix(qid, randomQID+":"):
+		q, err = randomQuestion(strings.TrimPrefix(strings.TrimPrefix(qid, randomQID), ":"), c)
+	case strings.HasPrefix(qid, tagQIDPrefix), strings.HasPrefix(qid, difficultyQIDPrefix):
+		qs, err = questionsByQuery(qid, c)
+	case strings.HasPrefix(qid, StudyListPrefix):
+		var l *StudyList
+		l, err = GetStudyList(strings.TrimPrefix(qid, StudyListPrefix))
+		if err == nil {
+			qs = l.Questions(c)
+		}
 	case strings.Contains(qid, "/"):
 		_, qs, err = ParseContestQID(qid, c, true)
 	}
@@ -85,6 +155,182 @@ func ParseQID(qid string, c Client) ([]*QuestionData, error) {
 	return qs, nil
 }
 
+// parseQIDList resolves every qid of a comma separated list, questions appear once in the result.
+func parseQIDList(qid string, c Client) ([]*QuestionData, error) {
+	var qs []*QuestionData
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(qid, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		found, err := parseQID(part, c)
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range found {
+			if !seen[q.TitleSlug] {
+				seen[q.TitleSlug] = true
+				qs = append(qs, q)
+			}
+		}
+	}
+	if len(qs) == 0 {
+		return nil, fmt.Errorf("invalid qid \"%s\": empty list", qid)
+	}
+	return qs, nil
+}
+
+// questionsInRange returns the cached questions whose IDs are in the range like "1-20", both ends included.
+func questionsInRange(qid string, c Client) ([]*QuestionData, error) {
+	m := qidRangePat.FindStringSubmatch(qid)
+	from, _ := strconv.Atoi(m[1])
+	to, _ := strconv.Atoi(m[2])
+	if from > to {
+		return nil, fmt.Errorf("range start %d is greater than its end %d", from, to)
+	}
+	var qs []*QuestionData
+	for _, q := range cachedQuestions(c) {
+		id, err := strconv.Atoi(q.QuestionFrontendId)
+		if err == nil && from <= id && id <= to {
+			qs = append(qs, q)
+		}
+	}
+	if len(qs) == 0 {
+		return nil, fmt.Errorf("no cached question in range %d-%d", from, to)
+	}
+	return qs, nil
+}
+
+// questionQuery filters cached questions, it's written like "tag:graph:medium:unsolved".
+type questionQuery struct {
+	tags       []string
+	difficulty string
+	status     string
+}
+
+func parseQuestionQuery(query string) (*questionQuery, error) {
+	qq := &questionQuery{}
+	if query == "" {
+		return qq, nil
+	}
+	terms := strings.Split(strings.ToLower(query), ":")
+	for i := 0; i < len(terms); i++ {
+		term := terms[i]
+		switch term {
+		case "tag", "difficulty":
+			if i+1 >= len(terms) || terms[i+1] == "" {
+				return nil, fmt.Errorf("missing value of %s", term)
+			}
+			i++
+			if term == "tag" {
+				qq.tags = append(qq.tags, terms[i])
+				continue
+			}
+			if !isDifficulty(terms[i]) {
+				return nil, fmt.Errorf("invalid difficulty %s, only easy, medium or hard is supported", terms[i])
+			}
+			qq.difficulty = terms[i]
+		case "easy", "medium", "hard":
+			qq.difficulty = term
+		case "solved", "unsolved", "tried":
+			qq.status = term
+		default:
+			return nil, fmt.Errorf("unknown filter %q", term)
+		}
+	}
+	return qq, nil
+}
+
+func isDifficulty(s string) bool {
+	return s == "easy" || s == "medium" || s == "hard"
+}
+
+func (qq *questionQuery) match(q *QuestionData) bool {
+	if qq.difficulty != "" && !strings.EqualFold(q.Difficulty, qq.difficulty) {
+		return false
+	}
+	// Status is "ac", "notac" or empty on leetcode.com, "AC", "TRIED" or "NOT_STARTED" on leetcode.cn.
+	solved := strings.EqualFold(q.Status, "ac")
+	tried := strings.EqualFold(q.Status, "notac") || strings.EqualFold(q.Status, "tried")
+	switch qq.status {
+	case "solved":
+		if !solved {
+			return false
+		}
+	case "unsolved":
+		if solved {
+			return false
+		}
+	case "tried":
+		if !tried {
+			return false
+		}
+	}
+	for _, tag := range qq.tags {
+		found := false
+		for _, t := range q.TopicTags {
+			if strings.EqualFold(t.Slug, tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// questionsByQuery returns the cached questions that match the query.
+// Status comes from the cache, run `leetgo cache update` to refresh it.
+func questionsByQuery(query string, c Client) ([]*QuestionData, error) {
+	qq, err := parseQuestionQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	var qs []*QuestionData
+	for _, q := range cachedQuestions(c) {
+		if qq.match(q) {
+			qs = append(qs, q)
+		}
+	}
+	if len(qs) == 0 {
+		return nil, fmt.Errorf("no cached question matches %q", query)
+	}
+	return qs, nil
+}
+
+func randomQuestion(query string, c Client) (*QuestionData, error) {
+	qs, err := questionsByQuery(query, c)
+	if err != nil {
+		return nil, err
+	}
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return qs[r.Intn(len(qs))], nil
+}
+
+// cachedQuestions returns all cached questions ordered by ID, questions without a numeric ID come last.
+func cachedQuestions(c Client) []*QuestionData {
+	all := GetCache(c).GetAllQuestions()
+	qs := make([]*QuestionData, len(all))
+	copy(qs, all)
+	for _, q := range qs {
+		q.client = c
+	}
+	sort.SliceStable(
+		qs, func(i, j int) bool {
+			a, errA := strconv.Atoi(qs[i].QuestionFrontendId)
+			b, errB := strconv.Atoi(qs[j].QuestionFrontendId)
+			if errA != nil || errB != nil {
+				return errA == nil && errB != nil
+			}
+			return a < b
+		},
+	)
+	return qs
+}
+
 func ParseContestQID(qid string, c Client, withQuestions bool) (*Contest, []*QuestionData, error) {
 	if len(qid) < 3 {
 		return nil, nil, errors.New("invalid contest qid")
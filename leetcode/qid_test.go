@@ -0,0 +1,55 @@
+package leetcode
+
+import (
+	"testing"
+)
+
+func TestQuestionQuery(t *testing.T) {
+	qs := []*QuestionData{
+		{TitleSlug: "two-sum", Difficulty: "Easy", Status: "ac", TopicTags: []TopicTag{{Slug: "array"}, {Slug: "hash-table"}}},
+		{TitleSlug: "clone-graph", Difficulty: "Medium", Status: "notac", TopicTags: []TopicTag{{Slug: "graph"}}},
+		{TitleSlug: "word-ladder", Difficulty: "HARD", Status: "NOT_STARTED", TopicTags: []TopicTag{{Slug: "graph"}}},
+	}
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"two-sum", "clone-graph", "word-ladder"}},
+		{"tag:graph", []string{"clone-graph", "word-ladder"}},
+		{"tag:graph:hard", []string{"word-ladder"}},
+		{"difficulty:easy", []string{"two-sum"}},
+		{"medium:unsolved", []string{"clone-graph"}},
+		{"tag:array:tag:hash-table:solved", []string{"two-sum"}},
+		{"tag:graph:tried", []string{"clone-graph"}},
+		{"tag:dp", nil},
+	}
+	for _, tc := range tests {
+		qq, err := parseQuestionQuery(tc.query)
+		if err != nil {
+			t.Errorf("parse %q: %v", tc.query, err)
+			continue
+		}
+		var got []string
+		for _, q := range qs {
+			if qq.match(q) {
+				got = append(got, q.TitleSlug)
+			}
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("%q: got %v, want %v", tc.query, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%q: got %v, want %v", tc.query, got, tc.want)
+				break
+			}
+		}
+	}
+
+	for _, query := range []string{"tag", "tag:", "difficulty:extreme", "popular"} {
+		if _, err := parseQuestionQuery(query); err == nil {
+			t.Errorf("%q: expected an error", query)
+		}
+	}
+}
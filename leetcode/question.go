@@ -464,6 +464,17 @@ func (q *QuestionData) GetCodeSnippet(slug string) string {
 	return ""
 }
 
+// SetCodeSnippet sets the code snippet of the language, replacing the existing one.
+func (q *QuestionData) SetCodeSnippet(slug string, code string) {
+	for i, snippet := range q.CodeSnippets {
+		if slug == snippet.LangSlug {
+			q.CodeSnippets[i].Code = code
+			return
+		}
+	}
+	q.CodeSnippets = append(q.CodeSnippets, CodeSnippet{LangSlug: slug, Code: code})
+}
+
 type FilenameTemplateData struct {
 	Id               string
 	Slug             string
@@ -475,6 +486,7 @@ type FilenameTemplateData struct {
 	ContestTitle     string
 	ContestShortSlug string
 	ContestSlug      string
+	TemplateData
 }
 
 func (q *QuestionData) formatQuestionId() (string, bool) {
@@ -511,6 +523,7 @@ func (q *QuestionData) GetFormattedFilename(lang string, filenameTemplate string
 		Lang:             lang,
 		SlugIsMeaningful: slugValid,
 		IsContest:        q.IsContest(),
+		TemplateData:     q.TemplateData(),
 	}
 	if q.IsContest() {
 		// Override id with contest question number
@@ -524,19 +537,7 @@ func (q *QuestionData) GetFormattedFilename(lang string, filenameTemplate string
 		data.ContestShortSlug = contestShortSlug(q.contest.TitleSlug)
 	}
 	tmpl := template.New("filename")
-	tmpl.Funcs(
-		template.FuncMap{
-			"lower": strings.ToLower,
-			"upper": strings.ToUpper,
-			"trim":  strings.TrimSpace,
-			"padWithZero": func(n int, s string) string {
-				return fmt.Sprintf("%0*s", n, s)
-			},
-			"toUnderscore": func(s string) string {
-				return strings.ReplaceAll(s, "-", "_")
-			},
-		},
-	)
+	tmpl.Funcs(utils.TemplateFuncs())
 	tmpl, err := tmpl.Parse(filenameTemplate)
 	if err != nil {
 		return "", err
@@ -0,0 +1,98 @@
+package leetcode
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+	"github.com/goccy/go-json"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/utils"
+)
+
+// Results of running or submitting the same code are cached locally, so we don't waste the rate limit.
+// The code should be normalized by the caller, e.g. comments removed.
+
+func resultCacheFile(kind string, q *QuestionData, lang string, code string, cases string) string {
+	cfg := config.Get()
+	h := sha256.New()
+	for _, s := range []string{string(cfg.LeetCode.Site), q.TitleSlug, lang, code, cases} {
+		sum := sha256.Sum256(utils.StringToBytes(s))
+		h.Write(sum[:])
+	}
+	return filepath.Join(cfg.ResultCacheDir(), kind+"-"+hex.EncodeToString(h.Sum(nil))+".json")
+}
+
+// cacheable reports whether a result with the status code is stable enough to be cached.
+func cacheable(code int) bool {
+	switch StatusCode(code) {
+	case Accepted, WrongAnswer, RuntimeError, CompileError:
+		return true
+	}
+	return false
+}
+
+func loadResult(file string, v any) bool {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return false
+	}
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		log.Debug("failed to load cached result", "file", file, "err", err)
+		return false
+	}
+	return true
+}
+
+func saveResult(file string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Debug("failed to marshal result", "err", err)
+		return
+	}
+	err = utils.CreateIfNotExists(file, false)
+	if err == nil {
+		err = os.WriteFile(file, data, 0o644)
+	}
+	if err != nil {
+		log.Debug("failed to cache result", "file", file, "err", err)
+	}
+}
+
+// GetCachedRunResult returns the cached result of running code with cases, or nil if not found.
+func GetCachedRunResult(q *QuestionData, lang string, code string, cases string) *RunCheckResult {
+	var r RunCheckResult
+	if !loadResult(resultCacheFile("run", q, lang, code, cases), &r) {
+		return nil
+	}
+	r.Cached = true
+	return &r
+}
+
+func CacheRunResult(q *QuestionData, lang string, code string, cases string, r *RunCheckResult) {
+	if !cacheable(r.StatusCode) {
+		return
+	}
+	saveResult(resultCacheFile("run", q, lang, code, cases), r)
+}
+
+// GetCachedSubmitResult returns the cached result of submitting code, or nil if not found.
+func GetCachedSubmitResult(q *QuestionData, lang string, code string) *SubmitCheckResult {
+	var r SubmitCheckResult
+	if !loadResult(resultCacheFile("submit", q, lang, code, ""), &r) {
+		return nil
+	}
+	r.Cached = true
+	return &r
+}
+
+func CacheSubmitResult(q *QuestionData, lang string, code string, r *SubmitCheckResult) {
+	if !cacheable(r.StatusCode) {
+		return
+	}
+	saveResult(resultCacheFile("submit", q, lang, code, ""), r)
+}
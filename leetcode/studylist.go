@@ -0,0 +1,135 @@
+package leetcode
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/j178/leetgo/config"
+)
+
+//go:embed lists/*.txt
+var builtinLists embed.FS
+
+// StudyListPrefix is the prefix of qids that refer to a question list, e.g. list:blind75.
+const StudyListPrefix = "list:"
+
+// StudyListItem is an entry of a study list, QID is a question slug or ID.
+type StudyListItem struct {
+	QID     string
+	Section string
+}
+
+// StudyList is a named list of questions, like Blind 75.
+type StudyList struct {
+	Name    string
+	Title   string
+	BuiltIn bool
+	Items   []StudyListItem
+}
+
+// parseStudyList parses a list file. The first comment line is the title,
+// following comment lines start new sections, other lines are qids.
+func parseStudyList(name string, content string) *StudyList {
+	l := &StudyList{Name: name, Title: name}
+	section := ""
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			text := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+			if i == 0 {
+				l.Title = text
+			} else {
+				section = text
+			}
+			continue
+		}
+		l.Items = append(l.Items, StudyListItem{QID: line, Section: section})
+	}
+	return l
+}
+
+// GetStudyList returns the list with the name, lists defined in config take precedence over the builtin ones.
+func GetStudyList(name string) (*StudyList, error) {
+	name = strings.ToLower(name)
+	if qids, ok := config.Get().Lists[name]; ok {
+		if err := checkListCycle(config.Get().Lists, name, nil); err != nil {
+			return nil, err
+		}
+		l := &StudyList{Name: name, Title: name}
+		for _, qid := range qids {
+			l.Items = append(l.Items, StudyListItem{QID: qid})
+		}
+		return l, nil
+	}
+	content, err := builtinLists.ReadFile(path.Join("lists", name+".txt"))
+	if err != nil {
+		return nil, fmt.Errorf("list %q not found, available lists: %s", name, strings.Join(StudyListNames(), ", "))
+	}
+	l := parseStudyList(name, string(content))
+	l.BuiltIn = true
+	return l, nil
+}
+
+// checkListCycle reports an error if the list refers to itself through "list:" entries,
+// resolving such a list would never end. path is the chain of lists that refer to the list.
+func checkListCycle(lists config.QuestionLists, name string, path []string) error {
+	path = append(append([]string(nil), path...), name)
+	for _, p := range path[:len(path)-1] {
+		if p == name {
+			return fmt.Errorf("list %q refers to itself: %s", name, strings.Join(path, " -> "))
+		}
+	}
+	for _, qid := range lists[name] {
+		for _, part := range strings.Split(qid, ",") {
+			part = strings.ToLower(strings.TrimSpace(part))
+			if !strings.HasPrefix(part, StudyListPrefix) {
+				continue
+			}
+			if err := checkListCycle(lists, strings.TrimPrefix(part, StudyListPrefix), path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// StudyListNames returns the names of all available lists.
+func StudyListNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	entries, _ := builtinLists.ReadDir("lists")
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ".txt")
+		seen[name] = true
+		names = append(names, name)
+	}
+	for name := range config.Get().Lists {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Questions resolves the items of the list to questions, items that cannot be found are skipped.
+func (l *StudyList) Questions(c Client) []*QuestionData {
+	var qs []*QuestionData
+	for _, item := range l.Items {
+		found, err := parseQID(item.QID, c)
+		if err != nil {
+			log.Warn("skip question in list", "list", l.Name, "qid", item.QID, "err", err)
+			continue
+		}
+		qs = append(qs, found...)
+	}
+	return qs
+}
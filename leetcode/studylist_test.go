@@ -0,0 +1,25 @@
+package leetcode
+
+import (
+	"testing"
+
+	"github.com/j178/leetgo/config"
+)
+
+func TestCheckListCycle(t *testing.T) {
+	lists := config.QuestionLists{
+		"self":  {"1", "list:self"},
+		"a":     {"two-sum", "15,list:b"},
+		"b":     {"list:c"},
+		"c":     {"list:A"},
+		"ok":    {"list:blind75", "list:leaf", "list:leaf"},
+		"leaf":  {"1"},
+		"entry": {"list:self"},
+	}
+	for name, wantErr := range map[string]bool{"self": true, "a": true, "entry": true, "ok": false, "leaf": false} {
+		err := checkListCycle(lists, name, nil)
+		if (err != nil) != wantErr {
+			t.Errorf("%s: got error %v, want error %v", name, err, wantErr)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package leetcode
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/k3a/html2text"
+
+	"github.com/j178/leetgo/config"
+	"github.com/j178/leetgo/utils"
+)
+
+// Example is an example test case shown in the question description.
+type Example struct {
+	Input  []string
+	Output string
+}
+
+// TemplateData is the question data shared by filename templates and code templates.
+type TemplateData struct {
+	Tags             []string
+	TagSlugs         []string
+	Hints            []string
+	SimilarQuestions []SimilarQuestion
+	Stats            Stats
+	AcRate           string
+	Category         string
+	Examples         []Example
+	Constraints      []string
+	Date             time.Time
+}
+
+// TemplateData returns the data of the question that can be used in templates.
+func (q *QuestionData) TemplateData() TemplateData {
+	return TemplateData{
+		Tags:             q.TagNames(),
+		TagSlugs:         q.TagSlugs(),
+		Hints:            q.GetHints(),
+		SimilarQuestions: q.SimilarQuestions,
+		Stats:            q.Stats,
+		AcRate:           q.Stats.ACRate,
+		Category:         string(q.CategoryTitle),
+		Examples:         q.Examples(),
+		Constraints:      q.Constraints(),
+		Date:             time.Now(),
+	}
+}
+
+// TagNames returns the names of the topic tags, translated names are used for Chinese.
+func (q *QuestionData) TagNames() []string {
+	names := make([]string, 0, len(q.TopicTags))
+	for _, tag := range q.TopicTags {
+		if config.Get().Language == config.ZH && tag.TranslatedName != "" {
+			names = append(names, tag.TranslatedName)
+		} else {
+			names = append(names, tag.Name)
+		}
+	}
+	return names
+}
+
+// GetHints returns the hints of the question as plain text.
+func (q *QuestionData) GetHints() []string {
+	hints := make([]string, 0, len(q.Hints))
+	for _, h := range q.Hints {
+		hints = append(hints, htmlToText(h))
+	}
+	return hints
+}
+
+// GetSimilarQuestions returns the similar questions, full data is loaded from the cache when available.
+func (q *QuestionData) GetSimilarQuestions() []*QuestionData {
+	questions := make([]*QuestionData, 0, len(q.SimilarQuestions))
+	for _, sq := range q.SimilarQuestions {
+		similar, err := QuestionFromCacheBySlug(sq.TitleSlug, q.client)
+		if err != nil {
+			similar = &QuestionData{
+				client:          q.client,
+				partial:         1,
+				TitleSlug:       sq.TitleSlug,
+				Title:           sq.Title,
+				TranslatedTitle: sq.TranslatedTitle,
+				Difficulty:      sq.Difficulty,
+			}
+		}
+		questions = append(questions, similar)
+	}
+	return questions
+}
+
+// Examples pairs example inputs with the outputs parsed from the description.
+func (q *QuestionData) Examples() []Example {
+	cases := q.GetTestCases()
+	outputs := q.ParseExampleOutputs()
+	argsNum := q.MetaData.NArg()
+	if argsNum == 0 {
+		return nil
+	}
+	var examples []Example
+	for i := 0; i+argsNum <= len(cases) && i/argsNum < len(outputs); i += argsNum {
+		examples = append(examples, Example{Input: cases[i : i+argsNum], Output: outputs[i/argsNum]})
+	}
+	return examples
+}
+
+var (
+	constraintsPat = regexp.MustCompile(`(?s)<strong[^>]*>\s*(?:Constraints|提示)\s*[:：]?\s*</strong>.*?<ul>(.*?)</ul>`)
+	listItemPat    = regexp.MustCompile(`(?s)<li>(.*?)</li>`)
+	supPat         = regexp.MustCompile(`<sup>(.*?)</sup>`)
+	subPat         = regexp.MustCompile(`<sub>(.*?)</sub>`)
+)
+
+// Constraints parses the constraints list from the description, e.g. "1 <= nums.length <= 10⁴".
+func (q *QuestionData) Constraints() []string {
+	content, _ := q.GetContent()
+	found := constraintsPat.FindStringSubmatch(content)
+	if found == nil {
+		return nil
+	}
+	var constraints []string
+	for _, item := range listItemPat.FindAllStringSubmatch(found[1], -1) {
+		text := htmlToText(item[1])
+		if text != "" {
+			constraints = append(constraints, text)
+		}
+	}
+	return constraints
+}
+
+func htmlToText(s string) string {
+	s = supPat.ReplaceAllStringFunc(
+		s, func(m string) string {
+			return utils.ReplaceSuperscript(supPat.FindStringSubmatch(m)[1])
+		},
+	)
+	s = subPat.ReplaceAllStringFunc(
+		s, func(m string) string {
+			return utils.ReplaceSubscript(subPat.FindStringSubmatch(m)[1])
+		},
+	)
+	s = html2text.HTML2Text(s)
+	s = strings.NewReplacer("\u00A0", " ", "\u200B", "").Replace(s)
+	return strings.TrimSpace(s)
+}
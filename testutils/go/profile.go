@@ -0,0 +1,62 @@
+package goutils
+
+import (
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"time"
+)
+
+const (
+	// ProfileEnv specifies which profile to collect: "cpu" or "mem".
+	ProfileEnv = "LEETGO_PROFILE"
+	// ProfileFileEnv specifies where to write the collected profile.
+	ProfileFileEnv = "LEETGO_PROFILE_FILE"
+	// TimingFileEnv specifies where to write the elapsed nanoseconds of the solution call.
+	TimingFileEnv = "LEETGO_TIMING_FILE"
+)
+
+// StartProfile starts profiling or timing if requested by leetgo through environment variables.
+// The returned function must be called right after the solution returns.
+func StartProfile() (stop func()) {
+	if file := os.Getenv(TimingFileEnv); file != "" {
+		start := time.Now()
+		return func() {
+			elapsed := time.Since(start)
+			err := os.WriteFile(file, []byte(strconv.FormatInt(elapsed.Nanoseconds(), 10)), 0o644)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	kind, file := os.Getenv(ProfileEnv), os.Getenv(ProfileFileEnv)
+	if kind == "" || file == "" {
+		return func() {}
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		panic(err)
+	}
+	switch kind {
+	case "cpu":
+		if err := pprof.StartCPUProfile(f); err != nil {
+			panic(err)
+		}
+		return func() {
+			pprof.StopCPUProfile()
+			_ = f.Close()
+		}
+	case "mem":
+		runtime.MemProfileRate = 1
+		return func() {
+			runtime.GC()
+			_ = pprof.Lookup("allocs").WriteTo(f, 0)
+			_ = f.Close()
+		}
+	}
+	_ = f.Close()
+	return func() {}
+}
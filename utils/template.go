@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/mitchellh/go-wordwrap"
+)
+
+// TemplateFuncs returns the functions available in all user templates,
+// a small subset of what sprig provides.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"lower":     strings.ToLower,
+		"upper":     strings.ToUpper,
+		"trim":      strings.TrimSpace,
+		"join":      func(sep string, s []string) string { return strings.Join(s, sep) },
+		"split":     func(sep string, s string) []string { return strings.Split(s, sep) },
+		"replace":   func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":  func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix": func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix": func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"default": func(def string, s string) string {
+			if s == "" {
+				return def
+			}
+			return s
+		},
+		"padWithZero": func(n int, s string) string {
+			return fmt.Sprintf("%0*s", n, s)
+		},
+		"toUnderscore": func(s string) string {
+			return strings.ReplaceAll(s, "-", "_")
+		},
+		"camelCase":  CamelCase,
+		"pascalCase": PascalCase,
+		"snakeCase":  SnakeCase,
+		"kebabCase":  KebabCase,
+		"now":        time.Now,
+		"date": func(layout string, t time.Time) string {
+			return t.Format(layout)
+		},
+		"truncate": TruncateString,
+		"indent": func(n int, s string) string {
+			return PrefixLines(strings.Repeat(" ", n), s)
+		},
+		"wrap": func(width int, s string) string {
+			return wordwrap.WrapString(s, uint(width))
+		},
+		"lineComment": func(comment string, s string) string {
+			return PrefixLines(comment+" ", s)
+		},
+	}
+}
+
+// splitWords splits s into words at non-alphanumeric characters and lower-to-upper case changes.
+func splitWords(s string) []string {
+	var words []string
+	var cur []rune
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(cur) > 0 {
+				words = append(words, string(cur))
+				cur = nil
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && len(cur) > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				words = append(words, string(cur))
+				cur = nil
+			}
+		}
+		cur = append(cur, r)
+	}
+	if len(cur) > 0 {
+		words = append(words, string(cur))
+	}
+	return words
+}
+
+func capitalize(s string) string {
+	runes := []rune(strings.ToLower(s))
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// CamelCase converts "two-sum" or "two_sum" to "twoSum".
+func CamelCase(s string) string {
+	words := splitWords(s)
+	for i, w := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(w)
+		} else {
+			words[i] = capitalize(w)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// PascalCase converts "two-sum" or "two_sum" to "TwoSum".
+func PascalCase(s string) string {
+	words := splitWords(s)
+	for i, w := range words {
+		words[i] = capitalize(w)
+	}
+	return strings.Join(words, "")
+}
+
+// SnakeCase converts "twoSum" or "two-sum" to "two_sum".
+func SnakeCase(s string) string {
+	return strings.ToLower(strings.Join(splitWords(s), "_"))
+}
+
+// KebabCase converts "twoSum" or "two_sum" to "two-sum".
+func KebabCase(s string) string {
+	return strings.ToLower(strings.Join(splitWords(s), "-"))
+}
+
+// TruncateString shortens s to at most n characters, "..." is appended if s is truncated.
+func TruncateString(n int, s string) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string(runes[:n])
+	}
+	return string(runes[:n-3]) + "..."
+}
+
+// PrefixLines adds prefix to every line of s, trailing spaces of blank lines are trimmed.
+func PrefixLines(prefix string, s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(prefix+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
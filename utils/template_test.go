@@ -0,0 +1,62 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/j178/leetgo/utils"
+)
+
+func TestCaseConversion(t *testing.T) {
+	testCases := []struct {
+		input  string
+		camel  string
+		pascal string
+		snake  string
+		kebab  string
+	}{
+		{"two-sum", "twoSum", "TwoSum", "two_sum", "two-sum"},
+		{"two_sum", "twoSum", "TwoSum", "two_sum", "two-sum"},
+		{"twoSum", "twoSum", "TwoSum", "two_sum", "two-sum"},
+		{"LRUCache", "lruCache", "LruCache", "lru_cache", "lru-cache"},
+		{"3sum closest", "3sumClosest", "3sumClosest", "3sum_closest", "3sum-closest"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.input, func(t *testing.T) {
+				if got := utils.CamelCase(tc.input); got != tc.camel {
+					t.Errorf("CamelCase(%q) = %q, want %q", tc.input, got, tc.camel)
+				}
+				if got := utils.PascalCase(tc.input); got != tc.pascal {
+					t.Errorf("PascalCase(%q) = %q, want %q", tc.input, got, tc.pascal)
+				}
+				if got := utils.SnakeCase(tc.input); got != tc.snake {
+					t.Errorf("SnakeCase(%q) = %q, want %q", tc.input, got, tc.snake)
+				}
+				if got := utils.KebabCase(tc.input); got != tc.kebab {
+					t.Errorf("KebabCase(%q) = %q, want %q", tc.input, got, tc.kebab)
+				}
+			},
+		)
+	}
+}
+
+func TestTruncateString(t *testing.T) {
+	testCases := []struct {
+		n        int
+		input    string
+		expected string
+	}{
+		{10, "two sum", "two sum"},
+		{7, "two sum", "two sum"},
+		{6, "two sum", "two..."},
+		{2, "two sum", "tw"},
+		{4, "两数之和啊", "两..."},
+	}
+
+	for _, tc := range testCases {
+		if got := utils.TruncateString(tc.n, tc.input); got != tc.expected {
+			t.Errorf("TruncateString(%d, %q) = %q, want %q", tc.n, tc.input, got, tc.expected)
+		}
+	}
+}